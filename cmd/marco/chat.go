@@ -0,0 +1,90 @@
+package main
+
+import (
+    "bufio"
+    "context"
+    "fmt"
+    "os"
+    "strings"
+
+    "github.com/spf13/cobra"
+
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/intentparser"
+    "github.com/VanTheBast/marco/pkg/mcp/orchestrator"
+)
+
+// newChatCmd builds the `marco chat` subcommand, which keeps a Session
+// open across multiple commands so follow-ups like "now do the same in
+// tests/" can refer back to what was already parsed and executed.
+func newChatCmd(cfg *config.Config) *cobra.Command {
+    var modelName string
+    var agentName string
+    var sessionID string
+
+    cmd := &cobra.Command{
+        Use:   "chat",
+        Short: "Start an interactive session that remembers prior commands",
+        RunE: func(cmd *cobra.Command, args []string) error {
+            var agent *config.Agent
+            if agentName != "" {
+                a, ok := orchestrator.LookupAgent(cfg, agentName)
+                if !ok {
+                    return fmt.Errorf("unknown agent %q", agentName)
+                }
+                agent = &a
+            }
+
+            session, err := intentparser.LoadSession(sessionID)
+            if err != nil {
+                return fmt.Errorf("loading session: %w", err)
+            }
+
+            ctx := context.Background()
+            fmt.Printf("marco chat (session %q, Ctrl+D to exit)\n", sessionID)
+            scanner := bufio.NewScanner(os.Stdin)
+            for {
+                fmt.Print("> ")
+                if !scanner.Scan() {
+                    return nil
+                }
+                input := strings.TrimSpace(scanner.Text())
+                if input == "" {
+                    continue
+                }
+
+                intent, err := intentparser.LLMParseInSession(ctx, session, input, modelName, agent)
+                if err != nil {
+                    fmt.Fprintln(os.Stderr, "parse error:", err)
+                    continue
+                }
+
+                events, err := orchestrator.RouteIntentForAgent(ctx, intent, agent)
+                if err != nil {
+                    if rerr := session.RecordResult(err.Error()); rerr != nil {
+                        fmt.Fprintln(os.Stderr, "saving session:", rerr)
+                    }
+                    fmt.Fprintln(os.Stderr, "exec error:", err)
+                    continue
+                }
+
+                var out strings.Builder
+                for event := range events {
+                    if event.Err != nil {
+                        out.WriteString(event.Err.Error())
+                        break
+                    }
+                    fmt.Print(event.Data)
+                    out.WriteString(event.Data)
+                }
+                if err := session.RecordResult(out.String()); err != nil {
+                    fmt.Fprintln(os.Stderr, "saving session:", err)
+                }
+            }
+        },
+    }
+    cmd.Flags().StringVarP(&modelName, "model", "m", "", "named model from the models/ directory to use")
+    cmd.Flags().StringVarP(&agentName, "agent", "a", "", "named agent profile scoping which modules/intents may be invoked")
+    cmd.Flags().StringVar(&sessionID, "session", "default", "session id to persist conversation history under")
+    return cmd
+}
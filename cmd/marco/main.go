@@ -8,6 +8,7 @@ import (
     "github.com/VanTheBast/marco/pkg/config"
 	"github.com/spf13/cobra"
     "github.com/VanTheBast/marco/pkg/mcp/orchestrator"
+    _ "github.com/VanTheBast/marco/pkg/mcp/fs"
     "github.com/VanTheBast/marco/pkg/intentparser"
 )
 
@@ -20,8 +21,13 @@ func main() {
         os.Exit(1)
     }
 
-    intentparser.Init(cfg.IntentParser.LLMAPIKey)
+    if err := intentparser.Init(cfg); err != nil {
+        fmt.Fprintln(os.Stderr, "Backend init error:", err)
+        os.Exit(1)
+    }
 
+	var modelName string
+	var agentName string
 	rootCmd := &cobra.Command{Use: "Marco CLI Agent"}
 	runCmd := &cobra.Command{
 		Use:   "marco [text]",
@@ -29,9 +35,18 @@ func main() {
 		RunE: func(cmd *cobra.Command, args []string) error {
             input := args[0]
 
+            var agent *config.Agent
+            if agentName != "" {
+                a, ok := orchestrator.LookupAgent(cfg, agentName)
+                if !ok {
+                    return fmt.Errorf("unknown agent %q", agentName)
+                }
+                agent = &a
+            }
+
             // 4. Parse intent (LLM-backed or fallback)
             ctx := context.Background()
-            intent, err := intentparser.LLMParse(ctx, input)
+            intent, err := intentparser.LLMParseForAgent(ctx, input, modelName, agent)
             if err != nil {
                 return fmt.Errorf("parse error: %w", err)
             }
@@ -45,16 +60,25 @@ func main() {
                 fmt.Printf("Key: %s, Value: %s\n", key, value)
             }
 
-            // 5. Route & execute
-            out, err := orchestrator.RouteIntent(intent)
+            // 5. Route & execute, printing output as it streams in
+            events, err := orchestrator.RouteIntentForAgent(ctx, intent, agent)
             if err != nil {
                 return fmt.Errorf("exec error: %w", err)
             }
-            fmt.Print(out)
+            for event := range events {
+                if event.Err != nil {
+                    return fmt.Errorf("exec error: %w", event.Err)
+                }
+                fmt.Print(event.Data)
+            }
             return nil
         },
 	}
+	runCmd.Flags().StringVarP(&modelName, "model", "m", "", "named model from the models/ directory to use")
+	runCmd.Flags().StringVarP(&agentName, "agent", "a", "", "named agent profile scoping which modules/intents may be invoked")
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(newServeCmd(cfg))
+	rootCmd.AddCommand(newChatCmd(cfg))
 	// rootCmd.Execute()
 
 	if err := rootCmd.Execute(); err != nil {
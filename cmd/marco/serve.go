@@ -0,0 +1,27 @@
+package main
+
+import (
+    "fmt"
+    "net/http"
+
+    "github.com/spf13/cobra"
+
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/server"
+)
+
+// newServeCmd builds the `marco serve` subcommand, which runs Marco as a
+// local HTTP server that streams intent execution over SSE.
+func newServeCmd(cfg *config.Config) *cobra.Command {
+    var addr string
+    cmd := &cobra.Command{
+        Use:   "serve",
+        Short: "Run Marco as a local HTTP server that streams intent execution over SSE",
+        RunE: func(cmd *cobra.Command, args []string) error {
+            fmt.Println("Marco serving on", addr)
+            return http.ListenAndServe(addr, server.Handler(cfg))
+        },
+    }
+    cmd.Flags().StringVar(&addr, "addr", "localhost:8787", "address to listen on")
+    return cmd
+}
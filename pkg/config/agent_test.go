@@ -0,0 +1,30 @@
+package config
+
+import "testing"
+
+func TestAgentAllows(t *testing.T) {
+    a := Agent{Name: "readonly-fs", Allow: []string{"fs.list_dir", "canvas"}}
+
+    cases := []struct {
+        module, intent string
+        want            bool
+    }{
+        {"fs", "list_dir", true},
+        {"fs", "find_pattern", false},
+        {"canvas", "draw", true},
+        {"canvas", "anything", true},
+        {"shell", "exec", false},
+    }
+    for _, c := range cases {
+        if got := a.Allows(c.module, c.intent); got != c.want {
+            t.Errorf("Allows(%q, %q) = %v, want %v", c.module, c.intent, got, c.want)
+        }
+    }
+}
+
+func TestAgentAllowsEmptyAllowListDeniesEverything(t *testing.T) {
+    a := Agent{Name: "locked-down"}
+    if a.Allows("fs", "list_dir") {
+        t.Errorf("expected an agent with no allow-list to deny everything")
+    }
+}
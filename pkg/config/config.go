@@ -1,9 +1,91 @@
 package config
 
-type IntentParserConfig struct {
-    LLMAPIKey string `yaml:"llm_api_key"`
+// Backend configures which LLM backend intentparser.Init should dial and
+// the connection details it needs to reach it. Temperature is a pointer
+// so a config can explicitly request 0 (deterministic sampling) without
+// it being confused with "not set in YAML", the same as
+// ModelParameters.Temperature.
+type Backend struct {
+    Type        string   `yaml:"type"` // openai | anthropic | ollama | grpc
+    Endpoint    string   `yaml:"endpoint"`
+    Model       string   `yaml:"model"`
+    APIKey      string   `yaml:"api_key"`
+    Temperature *float64 `yaml:"temperature"`
+    TopP        float64  `yaml:"top_p"`
+    MaxTokens   int      `yaml:"max_tokens"`
+}
+
+// ModelParameters holds sampling knobs for a Model, analogous to LocalAI's
+// per-model parameter blocks. Temperature is a pointer so a model can
+// explicitly request 0 (deterministic sampling) without it being confused
+// with "not set in YAML".
+type ModelParameters struct {
+    Temperature *float64 `yaml:"temperature"`
+    TopP        float64  `yaml:"top_p"`
+    MaxTokens   int      `yaml:"max_tokens"`
+}
+
+// PromptTemplates holds the named Go templates a Model renders instead of
+// intentparser's built-in prompts.
+type PromptTemplates struct {
+    System      string `yaml:"system"`
+    IntentParse string `yaml:"intent_parse"`
+    // Examples holds few-shot examples for the intent-parse prompt, so a
+    // model config can steer parsing with sample command/intent pairs
+    // instead of (or alongside) overriding IntentParse wholesale. The
+    // default prompt has them rendered ahead of the user's command; a
+    // custom IntentParse template gets them as {{.Examples}} and places
+    // them itself.
+    Examples string `yaml:"examples"`
+}
+
+// Model is a single named model, loaded from its own YAML file under the
+// models/ directory next to config.yaml.
+type Model struct {
+    Name    string `yaml:"name"`
+    Backend string `yaml:"backend"` // openai | anthropic | ollama | grpc
+    // Endpoint and APIKey override the top-level Backend's connection
+    // details for this model. They matter most when Backend names a
+    // different backend type than the global default (e.g. the default
+    // is "openai" but this model wants "ollama" at localhost:11434) and
+    // so can't just inherit the default's endpoint/api_key.
+    Endpoint        string          `yaml:"endpoint"`
+    APIKey          string          `yaml:"api_key"`
+    Parameters      ModelParameters `yaml:"parameters"`
+    PromptTemplates PromptTemplates `yaml:"prompt_templates"`
+}
+
+// Agent scopes which modules/intents the LLM may invoke and what system
+// prompt it's given, so a single tool surface can be split into
+// task-specialized, safety-scoped profiles.
+type Agent struct {
+    Name         string   `yaml:"name"`
+    SystemPrompt string   `yaml:"system_prompt"`
+    // Allow lists the modules/intents this agent may invoke. Each entry
+    // is either a bare module name (every intent in that module) or
+    // "module.intent" (just that one).
+    Allow []string `yaml:"allow"`
+}
+
+// Allows reports whether the agent permits invoking module/intent.
+func (a Agent) Allows(module, intent string) bool {
+    for _, entry := range a.Allow {
+        if entry == module || entry == module+"."+intent {
+            return true
+        }
+    }
+    return false
 }
 
 type Config struct {
-    IntentParser IntentParserConfig `yaml:"intentparser"`
+    Backend Backend `yaml:"backend"`
+
+    // Agents lists user-defined agent profiles. The orchestrator package
+    // also ships a few built-in profiles that apply when no config entry
+    // of the same name exists.
+    Agents []Agent `yaml:"agents"`
+
+    // Models holds every model defined under the models/ directory,
+    // keyed by Model.Name. Populated by Load, not by the top-level YAML.
+    Models map[string]Model `yaml:"-"`
 }
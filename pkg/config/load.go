@@ -44,8 +44,16 @@ func Load() (*Config, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("parsing config: %w", err)
 	}
-	if cfg.IntentParser.LLMAPIKey == "" {
-		return nil, fmt.Errorf("intentparser.llm_api_key is empty in %s", path)
+	if cfg.Backend.Type == "" {
+		cfg.Backend.Type = "openai"
 	}
+
+	modelsDir := filepath.Join(filepath.Dir(path), "models")
+	models, err := loadModels(modelsDir)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Models = models
+
 	return &cfg, nil
 }
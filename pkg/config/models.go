@@ -0,0 +1,52 @@
+package config
+
+import (
+    "errors"
+    "fmt"
+    "os"
+    "path/filepath"
+
+    "gopkg.in/yaml.v3"
+)
+
+// loadModels walks dir for *.yaml/*.yml files, each defining one Model,
+// and returns them keyed by Model.Name. A missing directory is not an
+// error: it just means no models are configured beyond the top-level
+// backend block.
+func loadModels(dir string) (map[string]Model, error) {
+    entries, err := os.ReadDir(dir)
+    if errors.Is(err, os.ErrNotExist) {
+        return map[string]Model{}, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("reading models dir %s: %w", dir, err)
+    }
+
+    models := make(map[string]Model, len(entries))
+    for _, e := range entries {
+        if e.IsDir() || !isYAMLFile(e.Name()) {
+            continue
+        }
+
+        path := filepath.Join(dir, e.Name())
+        data, err := os.ReadFile(path)
+        if err != nil {
+            return nil, fmt.Errorf("reading model config %s: %w", path, err)
+        }
+
+        var m Model
+        if err := yaml.Unmarshal(data, &m); err != nil {
+            return nil, fmt.Errorf("parsing model config %s: %w", path, err)
+        }
+        if m.Name == "" {
+            return nil, fmt.Errorf("model config %s missing required field %q", path, "name")
+        }
+        models[m.Name] = m
+    }
+    return models, nil
+}
+
+func isYAMLFile(name string) bool {
+    ext := filepath.Ext(name)
+    return ext == ".yaml" || ext == ".yml"
+}
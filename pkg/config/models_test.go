@@ -0,0 +1,92 @@
+package config
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func writeModelFile(t *testing.T, dir, name, contents string) {
+    t.Helper()
+    if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644); err != nil {
+        t.Fatalf("writing %s: %v", name, err)
+    }
+}
+
+func TestLoadModelsParsesEachFile(t *testing.T) {
+    dir := t.TempDir()
+    writeModelFile(t, dir, "fast.yaml", `
+name: fast
+backend: openai
+parameters:
+  temperature: 0
+  top_p: 0.9
+  max_tokens: 256
+prompt_templates:
+  system: "You are fast."
+  examples: "Example: \"list files\" -> fs.list_dir"
+`)
+    writeModelFile(t, dir, "ignored.txt", "not yaml")
+
+    models, err := loadModels(dir)
+    if err != nil {
+        t.Fatalf("loadModels: %v", err)
+    }
+    if len(models) != 1 {
+        t.Fatalf("loadModels returned %d models, want 1", len(models))
+    }
+
+    m, ok := models["fast"]
+    if !ok {
+        t.Fatalf("expected model %q to be loaded", "fast")
+    }
+    if m.Parameters.Temperature == nil || *m.Parameters.Temperature != 0 {
+        t.Errorf("Temperature = %v, want pointer to 0 (explicitly set, not absent)", m.Parameters.Temperature)
+    }
+    if m.Parameters.TopP != 0.9 {
+        t.Errorf("TopP = %v, want 0.9", m.Parameters.TopP)
+    }
+    if m.Parameters.MaxTokens != 256 {
+        t.Errorf("MaxTokens = %v, want 256", m.Parameters.MaxTokens)
+    }
+    if want := "Example: \"list files\" -> fs.list_dir"; m.PromptTemplates.Examples != want {
+        t.Errorf("PromptTemplates.Examples = %q, want %q", m.PromptTemplates.Examples, want)
+    }
+}
+
+func TestLoadModelsTemperatureUnsetIsNil(t *testing.T) {
+    dir := t.TempDir()
+    writeModelFile(t, dir, "plain.yaml", `
+name: plain
+backend: openai
+`)
+
+    models, err := loadModels(dir)
+    if err != nil {
+        t.Fatalf("loadModels: %v", err)
+    }
+    if models["plain"].Parameters.Temperature != nil {
+        t.Errorf("Temperature = %v, want nil for a model that doesn't set it", *models["plain"].Parameters.Temperature)
+    }
+}
+
+func TestLoadModelsMissingNameErrors(t *testing.T) {
+    dir := t.TempDir()
+    writeModelFile(t, dir, "noname.yaml", `
+backend: openai
+`)
+
+    if _, err := loadModels(dir); err == nil {
+        t.Errorf("expected an error for a model config missing \"name\"")
+    }
+}
+
+func TestLoadModelsMissingDirIsNotAnError(t *testing.T) {
+    models, err := loadModels(filepath.Join(t.TempDir(), "does-not-exist"))
+    if err != nil {
+        t.Fatalf("loadModels: %v", err)
+    }
+    if len(models) != 0 {
+        t.Errorf("loadModels returned %d models, want 0", len(models))
+    }
+}
@@ -0,0 +1,21 @@
+package intentparser
+
+import (
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/mcp/registry"
+)
+
+// filterToolsForAgent narrows tools down to the ones agent's allow-list
+// permits. A nil agent permits everything.
+func filterToolsForAgent(tools []registry.Tool, agent *config.Agent) []registry.Tool {
+    if agent == nil {
+        return tools
+    }
+    out := make([]registry.Tool, 0, len(tools))
+    for _, t := range tools {
+        if agent.Allows(t.Module, t.Name) {
+            out = append(out, t)
+        }
+    }
+    return out
+}
@@ -0,0 +1,34 @@
+package intentparser
+
+import (
+    "testing"
+
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/mcp/registry"
+)
+
+func TestFilterToolsForAgentNilAgentPermitsAll(t *testing.T) {
+    tools := []registry.Tool{{Module: "fs", Name: "list_dir"}, {Module: "shell", Name: "exec"}}
+    if got := filterToolsForAgent(tools, nil); len(got) != len(tools) {
+        t.Errorf("filterToolsForAgent(nil) = %d tools, want %d (unrestricted)", len(got), len(tools))
+    }
+}
+
+func TestFilterToolsForAgentNarrowsToAllowList(t *testing.T) {
+    tools := []registry.Tool{
+        {Module: "fs", Name: "list_dir"},
+        {Module: "fs", Name: "find_pattern"},
+        {Module: "shell", Name: "exec"},
+    }
+    agent := &config.Agent{Name: "readonly-fs", Allow: []string{"fs.list_dir", "fs.find_pattern"}}
+
+    got := filterToolsForAgent(tools, agent)
+    if len(got) != 2 {
+        t.Fatalf("filterToolsForAgent = %d tools, want 2", len(got))
+    }
+    for _, tool := range got {
+        if tool.Module != "fs" {
+            t.Errorf("unexpected tool leaked through allow-list: %s/%s", tool.Module, tool.Name)
+        }
+    }
+}
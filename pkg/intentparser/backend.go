@@ -0,0 +1,139 @@
+package intentparser
+
+import (
+    "context"
+    "fmt"
+    "sync"
+
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/mcp/registry"
+)
+
+// Backend is implemented by anything that can turn a rendered prompt into
+// a raw completion string. LLMParse unmarshals the result as JSON, so any
+// backend implementation is expected to honor the "output only JSON"
+// instruction baked into the prompt it is given.
+type Backend interface {
+    Parse(ctx context.Context, systemPrompt, prompt string) (string, error)
+}
+
+// ToolCall is the structured result of a function-calling-capable backend
+// selecting a tool and its arguments.
+type ToolCall struct {
+    Module string
+    Name   string
+    Params map[string]string
+}
+
+// ToolCallingBackend is implemented by backends that can be handed a set
+// of function/tool definitions and return a validated tool call directly,
+// instead of free-form text that must be parsed as JSON. LLMParse prefers
+// this over Backend.Parse whenever the backend supports it.
+type ToolCallingBackend interface {
+    Backend
+    ParseWithTools(ctx context.Context, systemPrompt, prompt string, tools []registry.Tool) (*ToolCall, error)
+}
+
+// GrammarBackend is implemented by backends that can be constrained to a
+// grammar (here, a JSON schema describing the only well-formed outputs)
+// instead of relying on a prompted instruction to emit JSON.
+type GrammarBackend interface {
+    Backend
+    ParseWithGrammar(ctx context.Context, systemPrompt, prompt, grammar string) (string, error)
+}
+
+var (
+    // cfg is the configuration Init was last called with, giving LLMParse
+    // access to the models/ catalog.
+    cfg *config.Config
+
+    // defaultBackend is built from cfg.Backend and used whenever no model
+    // name is given.
+    defaultBackend Backend
+
+    // backendCache holds the per-model backends built lazily by
+    // backendForModel, keyed by model name.
+    backendCacheMu sync.Mutex
+    backendCache   = map[string]Backend{}
+)
+
+// Init builds the default backend described by c.Backend and records c so
+// LLMParse can look up per-model backends from c.Models. This function
+// must be called once before invoking LLMParse.
+func Init(c *config.Config) error {
+    b, err := newBackend(c.Backend)
+    if err != nil {
+        return err
+    }
+    cfg = c
+    defaultBackend = b
+    backendCache = map[string]Backend{}
+    return nil
+}
+
+// newBackend constructs the Backend named by bc.Type.
+func newBackend(bc config.Backend) (Backend, error) {
+    switch bc.Type {
+    case "", "openai":
+        return newOpenAIBackend(bc), nil
+    case "anthropic":
+        return newAnthropicBackend(bc), nil
+    case "ollama":
+        return newOllamaBackend(bc), nil
+    case "grpc":
+        return newGRPCBackend(bc)
+    default:
+        return nil, fmt.Errorf("intentparser: unknown backend type %q", bc.Type)
+    }
+}
+
+// backendForModel resolves the Backend and Model for modelName. An empty
+// modelName returns the default backend installed by Init and a nil
+// Model, so callers fall back to the built-in prompts.
+func backendForModel(modelName string) (Backend, *config.Model, error) {
+    if modelName == "" {
+        if defaultBackend == nil {
+            return nil, nil, fmt.Errorf("intentparser: backend not initialized, call Init first")
+        }
+        return defaultBackend, nil, nil
+    }
+
+    model, ok := cfg.Models[modelName]
+    if !ok {
+        return nil, nil, fmt.Errorf("intentparser: unknown model %q", modelName)
+    }
+
+    backendCacheMu.Lock()
+    defer backendCacheMu.Unlock()
+    if b, ok := backendCache[modelName]; ok {
+        return b, &model, nil
+    }
+
+    bc := cfg.Backend
+    if model.Backend != "" {
+        bc.Type = model.Backend
+    }
+    if model.Endpoint != "" {
+        bc.Endpoint = model.Endpoint
+    }
+    if model.APIKey != "" {
+        bc.APIKey = model.APIKey
+    }
+    bc.Model = model.Name
+    if model.Parameters.Temperature != nil {
+        bc.Temperature = model.Parameters.Temperature
+    }
+    if model.Parameters.TopP != 0 {
+        bc.TopP = model.Parameters.TopP
+    }
+    if model.Parameters.MaxTokens != 0 {
+        bc.MaxTokens = model.Parameters.MaxTokens
+    }
+
+    b, err := newBackend(bc)
+    if err != nil {
+        return nil, nil, fmt.Errorf("intentparser: building backend for model %q: %w", modelName, err)
+    }
+    backendCache[modelName] = b
+    return b, &model, nil
+}
@@ -0,0 +1,169 @@
+package intentparser
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+
+    "github.com/anthropics/anthropic-sdk-go"
+    "github.com/anthropics/anthropic-sdk-go/option"
+
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/mcp/registry"
+)
+
+// defaultAnthropicMaxTokens is used when neither the backend block nor a
+// model config sets max_tokens;
+// the Anthropic API requires MaxTokens on every request.
+const defaultAnthropicMaxTokens = 1024
+
+// anthropicBackend talks to the Anthropic Messages API.
+type anthropicBackend struct {
+    client      anthropic.Client
+    model       string
+    temperature *float64
+    topP        float64
+    maxTokens   int
+}
+
+func newAnthropicBackend(cfg config.Backend) *anthropicBackend {
+    opts := []option.RequestOption{option.WithAPIKey(cfg.APIKey)}
+    if cfg.Endpoint != "" {
+        opts = append(opts, option.WithBaseURL(cfg.Endpoint))
+    }
+    model := cfg.Model
+    if model == "" {
+        model = "claude-3-5-sonnet-latest"
+    }
+    return &anthropicBackend{
+        client:      anthropic.NewClient(opts...),
+        model:       model,
+        temperature: cfg.Temperature,
+        topP:        cfg.TopP,
+        maxTokens:   cfg.MaxTokens,
+    }
+}
+
+func (b *anthropicBackend) Parse(ctx context.Context, systemPrompt, prompt string) (string, error) {
+    maxTokens := int64(b.maxTokens)
+    if maxTokens == 0 {
+        maxTokens = defaultAnthropicMaxTokens
+    }
+
+    params := anthropic.MessageNewParams{
+        Model:     b.model,
+        MaxTokens: maxTokens,
+        System:    []anthropic.TextBlockParam{{Text: systemPrompt}},
+        Messages: []anthropic.MessageParam{
+            anthropic.NewUserMessage(anthropic.NewTextBlock(prompt)),
+        },
+    }
+    if b.temperature != nil {
+        params.Temperature = anthropic.Float(*b.temperature)
+    }
+    if b.topP != 0 {
+        params.TopP = anthropic.Float(b.topP)
+    }
+
+    resp, err := b.client.Messages.New(ctx, params)
+    if err != nil {
+        return "", fmt.Errorf("anthropic backend: %w", err)
+    }
+    if len(resp.Content) == 0 {
+        return "", fmt.Errorf("anthropic backend: empty response")
+    }
+    return resp.Content[0].Text, nil
+}
+
+// ParseWithTools hands the registry's tools to Anthropic as native tool
+// definitions and forces a tool_use block in the reply, so its input
+// already matches the tool's JSON schema instead of being parsed out of
+// free-form text.
+func (b *anthropicBackend) ParseWithTools(ctx context.Context, systemPrompt, prompt string, tools []registry.Tool) (*ToolCall, error) {
+    maxTokens := int64(b.maxTokens)
+    if maxTokens == 0 {
+        maxTokens = defaultAnthropicMaxTokens
+    }
+
+    params := anthropic.MessageNewParams{
+        Model:     b.model,
+        MaxTokens: maxTokens,
+        System:    []anthropic.TextBlockParam{{Text: systemPrompt}},
+        Messages: []anthropic.MessageParam{
+            anthropic.NewUserMessage(anthropic.NewTextBlock(prompt)),
+        },
+        Tools:      toAnthropicTools(tools),
+        ToolChoice: anthropic.ToolChoiceUnionParam{OfAny: &anthropic.ToolChoiceAnyParam{}},
+    }
+    if b.temperature != nil {
+        params.Temperature = anthropic.Float(*b.temperature)
+    }
+    if b.topP != 0 {
+        params.TopP = anthropic.Float(b.topP)
+    }
+
+    resp, err := b.client.Messages.New(ctx, params)
+    if err != nil {
+        return nil, fmt.Errorf("anthropic backend: %w", err)
+    }
+
+    for _, block := range resp.Content {
+        toolUse := block.AsToolUse()
+        if toolUse.ID == "" {
+            continue
+        }
+        module, name, ok := splitToolName(toolUse.Name)
+        if !ok {
+            return nil, fmt.Errorf("anthropic backend: unrecognized tool name %q", toolUse.Name)
+        }
+        var params map[string]string
+        if err := json.Unmarshal(toolUse.Input, &params); err != nil {
+            return nil, fmt.Errorf("anthropic backend: invalid tool input: %w", err)
+        }
+        return &ToolCall{Module: module, Name: name, Params: params}, nil
+    }
+    return nil, fmt.Errorf("anthropic backend: model returned no tool_use block")
+}
+
+// toAnthropicTools converts the registry's module-agnostic tool
+// descriptions into Anthropic's native tool definitions, joining
+// module/name the same way toOpenAITools does so splitToolName works
+// regardless of which backend produced the call.
+func toAnthropicTools(tools []registry.Tool) []anthropic.ToolUnionParam {
+    out := make([]anthropic.ToolUnionParam, 0, len(tools))
+    for _, t := range tools {
+        props, _ := t.Parameters["properties"].(map[string]interface{})
+        out = append(out, anthropic.ToolUnionParam{
+            OfTool: &anthropic.ToolParam{
+                Name:        t.Module + toolNameSep + t.Name,
+                Description: anthropic.String(t.Description),
+                InputSchema: anthropic.ToolInputSchemaParam{
+                    Properties: props,
+                    Required:   toRequiredFields(t.Parameters["required"]),
+                },
+            },
+        })
+    }
+    return out
+}
+
+// toRequiredFields normalizes a JSON-schema "required" array into the
+// []string Anthropic's tool schema expects. Tools built directly in Go
+// (like fs's) set it as []string; one that had round-tripped through
+// JSON would carry []interface{} instead.
+func toRequiredFields(v interface{}) []string {
+    switch r := v.(type) {
+    case []string:
+        return r
+    case []interface{}:
+        out := make([]string, 0, len(r))
+        for _, e := range r {
+            if s, ok := e.(string); ok {
+                out = append(out, s)
+            }
+        }
+        return out
+    default:
+        return nil
+    }
+}
@@ -0,0 +1,65 @@
+package intentparser
+
+import (
+    "context"
+    "fmt"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/credentials/insecure"
+
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/intentparser/pb"
+)
+
+// grpcBackend delegates prompt parsing to an out-of-process IntentBackend
+// gRPC service, letting Marco run against any model runtime that speaks
+// the protocol in proto/intentbackend.proto.
+type grpcBackend struct {
+    conn        *grpc.ClientConn
+    client      pb.IntentBackendClient
+    temperature *float64
+}
+
+func newGRPCBackend(cfg config.Backend) (*grpcBackend, error) {
+    if cfg.Endpoint == "" {
+        return nil, fmt.Errorf("grpc backend: endpoint is required")
+    }
+    conn, err := grpc.NewClient(cfg.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+    if err != nil {
+        return nil, fmt.Errorf("grpc backend: dialing %s: %w", cfg.Endpoint, err)
+    }
+    return &grpcBackend{
+        conn:        conn,
+        client:      pb.NewIntentBackendClient(conn),
+        temperature: cfg.Temperature,
+    }, nil
+}
+
+func (b *grpcBackend) Parse(ctx context.Context, systemPrompt, prompt string) (string, error) {
+    return b.call(ctx, systemPrompt, prompt, "")
+}
+
+// ParseWithGrammar constrains the remote backend's output via the
+// ParseRequest.Grammar field.
+func (b *grpcBackend) ParseWithGrammar(ctx context.Context, systemPrompt, prompt, grammar string) (string, error) {
+    return b.call(ctx, systemPrompt, prompt, grammar)
+}
+
+func (b *grpcBackend) call(ctx context.Context, systemPrompt, prompt, grammar string) (string, error) {
+    // ParseRequest.temperature is a plain proto3 double, so an unset
+    // temperature is sent as 0, the same as an explicit 0.
+    var temperature float64
+    if b.temperature != nil {
+        temperature = *b.temperature
+    }
+    resp, err := b.client.Parse(ctx, &pb.ParseRequest{
+        Prompt:       prompt,
+        SystemPrompt: systemPrompt,
+        Temperature:  temperature,
+        Grammar:      grammar,
+    })
+    if err != nil {
+        return "", fmt.Errorf("grpc backend: %w", err)
+    }
+    return resp.Text, nil
+}
@@ -0,0 +1,88 @@
+package intentparser
+
+import (
+    "context"
+    "net"
+    "testing"
+
+    "google.golang.org/grpc"
+
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/intentparser/pb"
+)
+
+// fakeIntentBackendServer is an IntentBackendServer that returns resp and
+// records the request it received in lastReq, so tests can assert on what
+// grpcBackend actually sent without a real model runtime.
+type fakeIntentBackendServer struct {
+    pb.UnimplementedIntentBackendServer
+    lastReq *pb.ParseRequest
+    resp    string
+}
+
+func (f *fakeIntentBackendServer) Parse(ctx context.Context, req *pb.ParseRequest) (*pb.ParseResponse, error) {
+    f.lastReq = req
+    return &pb.ParseResponse{Text: f.resp}, nil
+}
+
+// startFakeGRPCServer runs srv's IntentBackendServer on a random loopback
+// port, stopping it when the test ends, and returns the address to dial.
+func startFakeGRPCServer(t *testing.T, srv *fakeIntentBackendServer) string {
+    t.Helper()
+
+    lis, err := net.Listen("tcp", "localhost:0")
+    if err != nil {
+        t.Fatalf("listening: %v", err)
+    }
+
+    s := grpc.NewServer()
+    pb.RegisterIntentBackendServer(s, srv)
+    go s.Serve(lis)
+    t.Cleanup(s.Stop)
+
+    return lis.Addr().String()
+}
+
+func TestNewGRPCBackendRequiresEndpoint(t *testing.T) {
+    if _, err := newGRPCBackend(config.Backend{}); err == nil {
+        t.Fatal("newGRPCBackend with no endpoint: want error, got nil")
+    }
+}
+
+func TestGRPCBackendParseRoundTrip(t *testing.T) {
+    fake := &fakeIntentBackendServer{resp: `{"module":"fs","intent":"list_dir","params":{}}`}
+    addr := startFakeGRPCServer(t, fake)
+
+    b, err := newGRPCBackend(config.Backend{Endpoint: addr})
+    if err != nil {
+        t.Fatalf("newGRPCBackend: %v", err)
+    }
+
+    got, err := b.Parse(context.Background(), "sys prompt", "list the repo root")
+    if err != nil {
+        t.Fatalf("Parse: %v", err)
+    }
+    if got != fake.resp {
+        t.Errorf("Parse = %q, want %q", got, fake.resp)
+    }
+    if fake.lastReq.SystemPrompt != "sys prompt" || fake.lastReq.Prompt != "list the repo root" {
+        t.Errorf("server received %+v, want system_prompt/prompt forwarded as given", fake.lastReq)
+    }
+}
+
+func TestGRPCBackendParseWithGrammarForwardsGrammar(t *testing.T) {
+    fake := &fakeIntentBackendServer{resp: `{"module":"fs","intent":"list_dir","params":{}}`}
+    addr := startFakeGRPCServer(t, fake)
+
+    b, err := newGRPCBackend(config.Backend{Endpoint: addr})
+    if err != nil {
+        t.Fatalf("newGRPCBackend: %v", err)
+    }
+
+    if _, err := b.ParseWithGrammar(context.Background(), "sys", "prompt", `{"type":"object"}`); err != nil {
+        t.Fatalf("ParseWithGrammar: %v", err)
+    }
+    if fake.lastReq.Grammar != `{"type":"object"}` {
+        t.Errorf("server received grammar %q, want it forwarded verbatim", fake.lastReq.Grammar)
+    }
+}
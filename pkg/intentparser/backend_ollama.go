@@ -0,0 +1,114 @@
+package intentparser
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+
+    "github.com/VanTheBast/marco/pkg/config"
+)
+
+// ollamaBackend talks to a local or self-hosted Ollama model server,
+// letting Marco run fully offline against a local model.
+type ollamaBackend struct {
+    endpoint    string
+    model       string
+    temperature *float64
+    topP        float64
+    maxTokens   int
+    httpClient  *http.Client
+}
+
+func newOllamaBackend(cfg config.Backend) *ollamaBackend {
+    endpoint := cfg.Endpoint
+    if endpoint == "" {
+        endpoint = "http://localhost:11434"
+    }
+    model := cfg.Model
+    if model == "" {
+        model = "llama3"
+    }
+    return &ollamaBackend{
+        endpoint:    endpoint,
+        model:       model,
+        temperature: cfg.Temperature,
+        topP:        cfg.TopP,
+        maxTokens:   cfg.MaxTokens,
+        httpClient:  &http.Client{},
+    }
+}
+
+// ollamaGenerateRequest mirrors the payload expected by Ollama's
+// POST /api/generate endpoint. Format carries a JSON schema constraining
+// the response when grammar-constrained parsing is requested.
+type ollamaGenerateRequest struct {
+    Model   string          `json:"model"`
+    Prompt  string          `json:"prompt"`
+    System  string          `json:"system,omitempty"`
+    Stream  bool            `json:"stream"`
+    Format  json.RawMessage `json:"format,omitempty"`
+    Options struct {
+        Temperature *float64 `json:"temperature,omitempty"`
+        TopP        float64 `json:"top_p,omitempty"`
+        NumPredict  int     `json:"num_predict,omitempty"`
+    } `json:"options"`
+}
+
+type ollamaGenerateResponse struct {
+    Response string `json:"response"`
+    Done     bool   `json:"done"`
+}
+
+func (b *ollamaBackend) Parse(ctx context.Context, systemPrompt, prompt string) (string, error) {
+    return b.generate(ctx, systemPrompt, prompt, nil)
+}
+
+// ParseWithGrammar constrains Ollama's output to grammar via the
+// /api/generate "format" field, which Ollama accepts as a JSON schema.
+func (b *ollamaBackend) ParseWithGrammar(ctx context.Context, systemPrompt, prompt, grammar string) (string, error) {
+    return b.generate(ctx, systemPrompt, prompt, json.RawMessage(grammar))
+}
+
+func (b *ollamaBackend) generate(ctx context.Context, systemPrompt, prompt string, format json.RawMessage) (string, error) {
+    reqBody := ollamaGenerateRequest{
+        Model:  b.model,
+        Prompt: prompt,
+        System: systemPrompt,
+        Stream: false,
+        Format: format,
+    }
+    reqBody.Options.Temperature = b.temperature
+    reqBody.Options.TopP = b.topP
+    reqBody.Options.NumPredict = b.maxTokens
+
+    payload, err := json.Marshal(reqBody)
+    if err != nil {
+        return "", fmt.Errorf("ollama backend: encoding request: %w", err)
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.endpoint+"/api/generate", bytes.NewReader(payload))
+    if err != nil {
+        return "", fmt.Errorf("ollama backend: building request: %w", err)
+    }
+    req.Header.Set("Content-Type", "application/json")
+
+    resp, err := b.httpClient.Do(req)
+    if err != nil {
+        return "", fmt.Errorf("ollama backend: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        body, _ := io.ReadAll(resp.Body)
+        return "", fmt.Errorf("ollama backend: unexpected status %d: %s", resp.StatusCode, body)
+    }
+
+    var out ollamaGenerateResponse
+    if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+        return "", fmt.Errorf("ollama backend: decoding response: %w", err)
+    }
+    return out.Response, nil
+}
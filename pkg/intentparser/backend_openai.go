@@ -0,0 +1,146 @@
+package intentparser
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "strings"
+
+    "github.com/openai/openai-go"
+    "github.com/openai/openai-go/option"
+
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/mcp/registry"
+)
+
+// toolNameSep joins a tool's module and intent name into the single
+// identifier OpenAI's function-calling API requires.
+const toolNameSep = "__"
+
+// openAIBackend talks to the OpenAI chat completions API.
+type openAIBackend struct {
+    client      openai.Client
+    model       string
+    temperature *float64
+    topP        float64
+    maxTokens   int
+}
+
+func newOpenAIBackend(cfg config.Backend) *openAIBackend {
+    opts := []option.RequestOption{option.WithAPIKey(cfg.APIKey)}
+    if cfg.Endpoint != "" {
+        opts = append(opts, option.WithBaseURL(cfg.Endpoint))
+    }
+    model := cfg.Model
+    if model == "" {
+        model = "gpt-3.5-turbo"
+    }
+    return &openAIBackend{
+        client:      openai.NewClient(opts...),
+        model:       model,
+        temperature: cfg.Temperature,
+        topP:        cfg.TopP,
+        maxTokens:   cfg.MaxTokens,
+    }
+}
+
+// params builds the sampling fields shared by Parse and ParseWithTools.
+func (b *openAIBackend) params() openai.ChatCompletionNewParams {
+    p := openai.ChatCompletionNewParams{
+        Model: b.model,
+    }
+    if b.temperature != nil {
+        p.Temperature = openai.Float(*b.temperature)
+    }
+    if b.topP != 0 {
+        p.TopP = openai.Float(b.topP)
+    }
+    if b.maxTokens != 0 {
+        p.MaxTokens = openai.Int(int64(b.maxTokens))
+    }
+    return p
+}
+
+func (b *openAIBackend) Parse(ctx context.Context, systemPrompt, prompt string) (string, error) {
+    messages := []openai.ChatCompletionMessageParamUnion{
+        openai.SystemMessage(systemPrompt),
+        openai.UserMessage(prompt),
+    }
+
+    reqParams := b.params()
+    reqParams.Messages = messages
+
+    resp, err := b.client.Chat.Completions.New(ctx, reqParams)
+    if err != nil {
+        return "", fmt.Errorf("openai backend: %w", err)
+    }
+    if len(resp.Choices) == 0 {
+        return "", fmt.Errorf("openai backend: empty response")
+    }
+    return resp.Choices[0].Message.Content, nil
+}
+
+// ParseWithTools hands the registry's tools to OpenAI as function
+// definitions and requires the model to return a tool call, so its
+// arguments already match the tool's JSON schema instead of being parsed
+// out of free-form text.
+func (b *openAIBackend) ParseWithTools(ctx context.Context, systemPrompt, prompt string, tools []registry.Tool) (*ToolCall, error) {
+    messages := []openai.ChatCompletionMessageParamUnion{
+        openai.SystemMessage(systemPrompt),
+        openai.UserMessage(prompt),
+    }
+
+    reqParams := b.params()
+    reqParams.Messages = messages
+    reqParams.Tools = toOpenAITools(tools)
+    reqParams.ToolChoice = openai.ChatCompletionToolChoiceOptionUnionParam{
+        OfAuto: openai.String("required"),
+    }
+
+    resp, err := b.client.Chat.Completions.New(ctx, reqParams)
+    if err != nil {
+        return nil, fmt.Errorf("openai backend: %w", err)
+    }
+    if len(resp.Choices) == 0 {
+        return nil, fmt.Errorf("openai backend: empty response")
+    }
+
+    calls := resp.Choices[0].Message.ToolCalls
+    if len(calls) == 0 {
+        return nil, fmt.Errorf("openai backend: model returned no tool call")
+    }
+
+    call := calls[0]
+    module, name, ok := splitToolName(call.Function.Name)
+    if !ok {
+        return nil, fmt.Errorf("openai backend: unrecognized tool name %q", call.Function.Name)
+    }
+
+    var params map[string]string
+    if err := json.Unmarshal([]byte(call.Function.Arguments), &params); err != nil {
+        return nil, fmt.Errorf("openai backend: invalid tool arguments: %w", err)
+    }
+    return &ToolCall{Module: module, Name: name, Params: params}, nil
+}
+
+func toOpenAITools(tools []registry.Tool) []openai.ChatCompletionToolParam {
+    out := make([]openai.ChatCompletionToolParam, 0, len(tools))
+    for _, t := range tools {
+        out = append(out, openai.ChatCompletionToolParam{
+            Function: openai.FunctionDefinitionParam{
+                Name:        t.Module + toolNameSep + t.Name,
+                Description: openai.String(t.Description),
+                Parameters:  t.Parameters,
+            },
+        })
+    }
+    return out
+}
+
+func splitToolName(full string) (module, name string, ok bool) {
+    parts := strings.SplitN(full, toolNameSep, 2)
+    if len(parts) != 2 {
+        return "", "", false
+    }
+    return parts[0], parts[1], true
+}
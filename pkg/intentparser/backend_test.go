@@ -0,0 +1,66 @@
+package intentparser
+
+import (
+    "testing"
+
+    "github.com/VanTheBast/marco/pkg/mcp/registry"
+)
+
+func findPatternTool() registry.Tool {
+    return registry.Tool{
+        Module:      "fs",
+        Name:        "find_pattern",
+        Description: "Recursively search files under a path for a literal text pattern.",
+        Parameters: map[string]interface{}{
+            "type": "object",
+            "properties": map[string]interface{}{
+                "pattern": map[string]interface{}{"type": "string"},
+                "path":    map[string]interface{}{"type": "string"},
+            },
+            "required": []string{"pattern"},
+        },
+    }
+}
+
+func TestToAnthropicToolsExtractsPerArgumentSchema(t *testing.T) {
+    out := toAnthropicTools([]registry.Tool{findPatternTool()})
+    if len(out) != 1 || out[0].OfTool == nil {
+        t.Fatalf("toAnthropicTools returned %#v, want a single populated tool", out)
+    }
+    tool := out[0].OfTool
+
+    wantName := "fs" + toolNameSep + "find_pattern"
+    if tool.Name != wantName {
+        t.Errorf("Name = %q, want %q", tool.Name, wantName)
+    }
+
+    props, ok := tool.InputSchema.Properties.(map[string]interface{})
+    if !ok {
+        t.Fatalf("Properties = %#v, want the per-argument schema map, not the whole JSON schema", tool.InputSchema.Properties)
+    }
+    if _, ok := props["pattern"]; !ok {
+        t.Errorf("Properties missing %q: %#v", "pattern", props)
+    }
+    if _, ok := props["type"]; ok {
+        t.Errorf("Properties still carries the top-level %q key: %#v", "type", props)
+    }
+
+    if len(tool.InputSchema.Required) != 1 || tool.InputSchema.Required[0] != "pattern" {
+        t.Errorf("Required = %v, want [%q]", tool.InputSchema.Required, "pattern")
+    }
+}
+
+func TestToOpenAIToolsCarriesTheFullSchema(t *testing.T) {
+    out := toOpenAITools([]registry.Tool{findPatternTool()})
+    if len(out) != 1 {
+        t.Fatalf("toOpenAITools returned %d tools, want 1", len(out))
+    }
+
+    wantName := "fs" + toolNameSep + "find_pattern"
+    if out[0].Function.Name != wantName {
+        t.Errorf("Name = %q, want %q", out[0].Function.Name, wantName)
+    }
+    if out[0].Function.Parameters["required"] == nil {
+        t.Errorf("Parameters = %#v, want the full JSON schema including \"required\"", out[0].Function.Parameters)
+    }
+}
@@ -1,22 +1,26 @@
 // Package intentparser provides functionality to parse free-form user commands
-// into structured Intent objects via an LLM backend.
+// into structured Intent objects via a pluggable LLM backend.
 package intentparser
 
 import (
     "context"
-    "encoding/json"
     "fmt"
 
-    "github.com/openai/openai-go"
-    "github.com/openai/openai-go/option"
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/mcp/registry"
 )
 
 const (
-    // systemPrompt is the system-level instruction to the LLM to emit only JSON.
-    systemPrompt = `You parse user commands into JSON intents. Output *only* JSON.`
-
-    // promptTemplate defines the few-shot examples and schema for transforming
-    // a raw user command into the desired JSON structure.
+    // systemPrompt is the system-level instruction to the LLM. Tool-calling
+    // backends use it alongside Tools/ToolChoice; backends without native
+    // function calling fall back to promptTemplate's "output only JSON"
+    // instruction, constrained by the grammar built from the registry.
+    systemPrompt = `You parse user commands into structured intents using the tools available to you.`
+
+    // promptTemplate is the fallback used for backends that can't be given
+    // function/tool definitions directly. It still asks for bare JSON, but
+    // callers pair it with a grammar generated from the registry so the
+    // output is constrained rather than merely requested.
     promptTemplate = `
 You are an intent parser. You must output *only* JSON matching this schema:
 
@@ -26,18 +30,14 @@ You are an intent parser. You must output *only* JSON matching this schema:
   "params": { ... }
 }
 
-Examples:
-User: "List all files in src"
-{"module":"fs","intent":"list_dir","params":{"path":"src"}}
-
-User: "Find TODO comments in pkg/"
-{"module":"fs","intent":"find_pattern","params":{"pattern":"TODO","path":"pkg"}}
-
 Now parse this command into JSON:
 ---
 %s
 ---
 `
+
+    // maxParseAttempts bounds the retry-with-error-feedback loop in LLMParse.
+    maxParseAttempts = 3
 )
 
 // Intent represents the parsed output from the LLM.
@@ -50,53 +50,80 @@ type Intent struct {
     Params map[string]string `json:"params"`
 }
 
-var (
-    // client is the shared OpenAI client instance. Must be initialized
-    // via Init before calling LLMParse.
-    client openai.Client
-)
+// LLMParse sends a natural-language command to the default backend and
+// returns the Intent it selects. It is equivalent to calling
+// LLMParseWithModel with an empty model name.
+func LLMParse(ctx context.Context, userCmd string) (*Intent, error) {
+    return LLMParseWithModel(ctx, userCmd, "")
+}
 
-// Init sets up the OpenAI client with the provided API key.
-// This function must be called once before invoking LLMParse.
-func Init(apiKey string) {
-    client = openai.NewClient(
-		option.WithAPIKey(apiKey),
-	)
+// LLMParseWithModel is like LLMParse, but looks modelName up in the
+// models/ directory loaded by config.Load and, when found, uses its
+// backend, parameters, and prompt templates instead of the defaults. A
+// modelName that is not in the catalog is an error. It is equivalent to
+// calling LLMParseForAgent with a nil agent.
+func LLMParseWithModel(ctx context.Context, userCmd, modelName string) (*Intent, error) {
+    return LLMParseForAgent(ctx, userCmd, modelName, nil)
 }
 
-// LLMParse sends a natural-language command to the LLM, expecting
-// a JSON response that matches our Intent struct.
-// It returns an Intent on success, or an error if parsing fails.
-func LLMParse(ctx context.Context, userCmd string) (*Intent, error) {
-    // Build the chat messages by cloning the static base messages
-    // and appending the formatted user prompt.
-     // Build messages
-     messages := []openai.ChatCompletionMessageParamUnion{
-        openai.SystemMessage(systemPrompt),
-        openai.UserMessage(fmt.Sprintf(promptTemplate, userCmd)),
+// LLMParseForAgent is like LLMParseWithModel, but scopes the tools/grammar
+// offered to the backend down to agent's allow-list and, when agent
+// defines one, uses its system prompt instead of the model's or package
+// default. Tool-calling-capable backends are given the (agent-filtered)
+// tool definitions directly; others are constrained with a grammar
+// generated from the same set. If the backend's output doesn't validate,
+// LLMParseForAgent retries with the validation error fed back into the
+// prompt.
+func LLMParseForAgent(ctx context.Context, userCmd, modelName string, agent *config.Agent) (*Intent, error) {
+    b, model, err := backendForModel(modelName)
+    if err != nil {
+        return nil, err
     }
 
-    // Create the chat completion
-    resp, err := client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
-        Model:    "gpt-3.5-turbo", // or openai.GPT4
-        Messages: messages,
-    })
-    if err != nil {
-        return nil, fmt.Errorf("LLM API error: %w", err)
+    tools := filterToolsForAgent(registry.Default.All(), agent)
+    sysPrompt := renderSystemPrompt(model)
+    if agent != nil && agent.SystemPrompt != "" {
+        sysPrompt = agent.SystemPrompt
     }
+    prompt := renderIntentPrompt(model, userCmd)
+    if _, ok := b.(ToolCallingBackend); ok && (model == nil || model.PromptTemplates.IntentParse == "") {
+        // Tool-calling backends are given the tool definitions, so they
+        // get the bare command instead of promptTemplate's JSON instruction.
+        prompt = renderExamples(model) + userCmd
+    }
+
+    return parseWithRetry(ctx, b, sysPrompt, prompt, tools)
+}
 
-    // Extract the JSON string
-    raw := resp.Choices[0].Message.Content
+// LLMParseInSession is like LLMParseForAgent, but appends userCmd to
+// session, sends the session's full history as context instead of just
+// userCmd, and records the resulting tool call back into session so
+// follow-up commands in the same session can refer to it. Callers should
+// call session.RecordResult once they've executed the returned Intent, so
+// the next turn also sees its output.
+func LLMParseInSession(ctx context.Context, session *Session, userCmd, modelName string, agent *config.Agent) (*Intent, error) {
+    session.Messages = append(session.Messages, Message{Role: "user", Content: userCmd})
 
-    // Unmarshal into your Intent struct
-    var intent Intent
-    if err := json.Unmarshal([]byte(raw), &intent); err != nil {
-        return nil, fmt.Errorf("invalid JSON from LLM: %w\nresponse: %s", err, raw)
+    intent, err := LLMParseForAgent(ctx, session.transcript(), modelName, agent)
+    if err != nil {
+        // Roll back the append so a failed parse doesn't leave an
+        // unpaired "user" message sitting in Session.Messages to be
+        // persisted by some later, unrelated turn's Save().
+        session.Messages = session.Messages[:len(session.Messages)-1]
+        return nil, err
     }
 
-    // Validate required fields
-    if intent.Module == "" || intent.Name == "" {
-        return nil, fmt.Errorf("parsed JSON missing fields: %+v", intent)
+    session.Messages = append(session.Messages, Message{
+        Role: "assistant",
+        ToolCall: &ToolCallRecord{
+            Module: intent.Module,
+            Name:   intent.Name,
+            Params: intent.Params,
+        },
+    })
+    if err := session.Save(); err != nil {
+        return nil, fmt.Errorf("saving session %s: %w", session.ID, err)
     }
-    return &intent, nil
+
+    return intent, nil
 }
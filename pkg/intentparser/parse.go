@@ -0,0 +1,131 @@
+package intentparser
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+
+    "github.com/VanTheBast/marco/pkg/mcp/registry"
+)
+
+// parseWithRetry drives parseOnce up to maxParseAttempts times, feeding
+// each failure back into the next attempt's prompt.
+func parseWithRetry(ctx context.Context, b Backend, sysPrompt, prompt string, tools []registry.Tool) (*Intent, error) {
+    var lastErr error
+    for attempt := 1; attempt <= maxParseAttempts; attempt++ {
+        intent, err := parseOnce(ctx, b, sysPrompt, prompt, tools, lastErr)
+        if err == nil {
+            return intent, nil
+        }
+        lastErr = err
+    }
+    return nil, fmt.Errorf("LLM backend error after %d attempts: %w", maxParseAttempts, lastErr)
+}
+
+// parseOnce makes a single attempt at turning prompt into an Intent using
+// b. If prevErr is non-nil, it is appended to the prompt so the model can
+// correct its previous mistake.
+func parseOnce(ctx context.Context, b Backend, sysPrompt, prompt string, tools []registry.Tool, prevErr error) (*Intent, error) {
+    if prevErr != nil {
+        prompt = fmt.Sprintf("%s\n\nYour previous response was invalid: %s\nTry again.", prompt, prevErr)
+    }
+
+    if tc, ok := b.(ToolCallingBackend); ok {
+        call, err := tc.ParseWithTools(ctx, sysPrompt, prompt, tools)
+        if err != nil {
+            return nil, fmt.Errorf("tool-calling backend: %w", err)
+        }
+        intent := &Intent{Module: call.Module, Name: call.Name, Params: call.Params}
+        if err := validateIntent(intent, tools); err != nil {
+            return nil, err
+        }
+        return intent, nil
+    }
+
+    raw, err := parseWithGrammarIfSupported(ctx, b, sysPrompt, prompt, tools)
+    if err != nil {
+        return nil, fmt.Errorf("LLM backend error: %w", err)
+    }
+
+    var intent Intent
+    if err := json.Unmarshal([]byte(raw), &intent); err != nil {
+        return nil, fmt.Errorf("invalid JSON from LLM: %w\nresponse: %s", err, raw)
+    }
+    if err := validateIntent(&intent, tools); err != nil {
+        return nil, err
+    }
+    return &intent, nil
+}
+
+// parseWithGrammarIfSupported constrains b's output to a grammar built
+// from the registry when b supports it, falling back to a plain prompt
+// otherwise.
+func parseWithGrammarIfSupported(ctx context.Context, b Backend, sysPrompt, prompt string, tools []registry.Tool) (string, error) {
+    if gb, ok := b.(GrammarBackend); ok {
+        return gb.ParseWithGrammar(ctx, sysPrompt, prompt, buildGrammar(tools))
+    }
+    return b.Parse(ctx, sysPrompt, prompt)
+}
+
+// validateIntent checks that intent has both fields set, names a tool
+// that is actually registered, and supplies every param that tool's
+// schema marks as required, so a model that omits one is told to
+// self-correct instead of failing later at execution time.
+func validateIntent(intent *Intent, tools []registry.Tool) error {
+    if intent.Module == "" || intent.Name == "" {
+        return fmt.Errorf("parsed intent missing fields: %+v", intent)
+    }
+    for _, t := range tools {
+        if t.Module == intent.Module && t.Name == intent.Name {
+            return validateRequiredParams(intent, t)
+        }
+    }
+    return fmt.Errorf("parsed intent %s/%s is not a registered tool", intent.Module, intent.Name)
+}
+
+// validateRequiredParams checks that intent.Params sets every param
+// tool.Parameters' JSON schema marks as required.
+func validateRequiredParams(intent *Intent, tool registry.Tool) error {
+    for _, name := range toRequiredFields(tool.Parameters["required"]) {
+        if intent.Params[name] == "" {
+            return fmt.Errorf("parsed intent %s/%s missing required param %q", intent.Module, intent.Name, name)
+        }
+    }
+    return nil
+}
+
+// buildGrammar renders a JSON schema that only admits the registered
+// module/intent pairs and their parameter schemas, for backends that
+// support constrained decoding (e.g. GBNF-capable local model servers)
+// instead of native function calling.
+func buildGrammar(tools []registry.Tool) string {
+    schema := map[string]interface{}{
+        "type": "object",
+        "properties": map[string]interface{}{
+            "module": map[string]interface{}{"type": "string"},
+            "intent": map[string]interface{}{"type": "string"},
+            "params": map[string]interface{}{"type": "object"},
+        },
+        "required": []string{"module", "intent", "params"},
+        "oneOf":    toolSchemas(tools),
+    }
+    b, err := json.Marshal(schema)
+    if err != nil {
+        return ""
+    }
+    return string(b)
+}
+
+func toolSchemas(tools []registry.Tool) []map[string]interface{} {
+    out := make([]map[string]interface{}, 0, len(tools))
+    for _, t := range tools {
+        out = append(out, map[string]interface{}{
+            "properties": map[string]interface{}{
+                "module": map[string]interface{}{"const": t.Module},
+                "intent": map[string]interface{}{"const": t.Name},
+                "params": t.Parameters,
+            },
+        })
+    }
+    return out
+}
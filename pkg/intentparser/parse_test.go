@@ -0,0 +1,169 @@
+package intentparser
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "strings"
+    "testing"
+
+    "github.com/VanTheBast/marco/pkg/mcp/registry"
+)
+
+// fakeBackend is a Backend that returns responses[0] on its first Parse
+// call, responses[1] on its second, and so on, repeating the last entry
+// once responses is exhausted. It records every prompt it was given so
+// tests can check that parseWithRetry feeds prevErr back in.
+type fakeBackend struct {
+    responses []string
+
+    calls   int
+    prompts []string
+}
+
+func (f *fakeBackend) Parse(ctx context.Context, systemPrompt, prompt string) (string, error) {
+    f.prompts = append(f.prompts, prompt)
+    idx := f.calls
+    if idx >= len(f.responses) {
+        idx = len(f.responses) - 1
+    }
+    f.calls++
+    return f.responses[idx], nil
+}
+
+func TestValidateIntent(t *testing.T) {
+    tools := []registry.Tool{{Module: "fs", Name: "list_dir"}}
+
+    if err := validateIntent(&Intent{Module: "fs", Name: "list_dir"}, tools); err != nil {
+        t.Errorf("expected registered intent to validate, got %v", err)
+    }
+    if err := validateIntent(&Intent{Module: "fs", Name: "delete_all"}, tools); err == nil {
+        t.Errorf("expected unregistered intent to fail validation")
+    }
+    if err := validateIntent(&Intent{Module: "fs"}, tools); err == nil {
+        t.Errorf("expected intent missing Name to fail validation")
+    }
+}
+
+func TestValidateIntentChecksRequiredParams(t *testing.T) {
+    tools := []registry.Tool{{
+        Module: "fs",
+        Name:   "find_pattern",
+        Parameters: map[string]interface{}{
+            "type":       "object",
+            "properties": map[string]interface{}{"pattern": map[string]interface{}{"type": "string"}},
+            "required":   []string{"pattern"},
+        },
+    }}
+
+    if err := validateIntent(&Intent{Module: "fs", Name: "find_pattern", Params: map[string]string{}}, tools); err == nil {
+        t.Errorf("expected intent missing required param %q to fail validation", "pattern")
+    }
+    if err := validateIntent(&Intent{Module: "fs", Name: "find_pattern", Params: map[string]string{"pattern": "TODO"}}, tools); err != nil {
+        t.Errorf("expected intent with required param set to validate, got %v", err)
+    }
+}
+
+func TestBuildGrammarIncludesEveryTool(t *testing.T) {
+    tools := []registry.Tool{
+        {Module: "fs", Name: "list_dir", Parameters: map[string]interface{}{"type": "object"}},
+        {Module: "fs", Name: "find_pattern", Parameters: map[string]interface{}{"type": "object"}},
+    }
+
+    var schema map[string]interface{}
+    if err := json.Unmarshal([]byte(buildGrammar(tools)), &schema); err != nil {
+        t.Fatalf("buildGrammar produced invalid JSON: %v", err)
+    }
+
+    oneOf, ok := schema["oneOf"].([]interface{})
+    if !ok || len(oneOf) != len(tools) {
+        t.Errorf("oneOf = %v, want %d entries", schema["oneOf"], len(tools))
+    }
+}
+
+func TestParseWithRetryRecoversAfterAnInvalidCall(t *testing.T) {
+    tools := []registry.Tool{{Module: "fs", Name: "list_dir"}}
+    b := &fakeBackend{responses: []string{
+        `{"module":"fs","intent":"delete_all","params":{}}`, // not a registered tool
+        `{"module":"fs","intent":"list_dir","params":{}}`,
+    }}
+
+    intent, err := parseWithRetry(context.Background(), b, "sys", "list the repo root", tools)
+    if err != nil {
+        t.Fatalf("parseWithRetry: %v", err)
+    }
+    if intent.Module != "fs" || intent.Name != "list_dir" {
+        t.Errorf("intent = %+v, want fs/list_dir", intent)
+    }
+    if b.calls != 2 {
+        t.Errorf("calls = %d, want 2 (one failure, one recovery)", b.calls)
+    }
+    if !strings.Contains(b.prompts[1], "Your previous response was invalid") {
+        t.Errorf("second prompt = %q, want it to feed back the first attempt's error", b.prompts[1])
+    }
+    if !strings.Contains(b.prompts[1], "not a registered tool") {
+        t.Errorf("second prompt = %q, want it to include the validation error", b.prompts[1])
+    }
+}
+
+func TestParseWithRetryRecoversAfterAMissingRequiredParam(t *testing.T) {
+    tools := []registry.Tool{{
+        Module:     "fs",
+        Name:       "find_pattern",
+        Parameters: map[string]interface{}{"required": []string{"pattern"}},
+    }}
+    b := &fakeBackend{responses: []string{
+        `{"module":"fs","intent":"find_pattern","params":{}}`, // missing "pattern"
+        `{"module":"fs","intent":"find_pattern","params":{"pattern":"TODO"}}`,
+    }}
+
+    intent, err := parseWithRetry(context.Background(), b, "sys", "find TODOs", tools)
+    if err != nil {
+        t.Fatalf("parseWithRetry: %v", err)
+    }
+    if intent.Params["pattern"] != "TODO" {
+        t.Errorf("intent.Params = %+v, want pattern=TODO", intent.Params)
+    }
+    if b.calls != 2 {
+        t.Errorf("calls = %d, want 2 (one failure, one recovery)", b.calls)
+    }
+    if !strings.Contains(b.prompts[1], "missing required param") {
+        t.Errorf("second prompt = %q, want it to feed back the missing-param error", b.prompts[1])
+    }
+}
+
+func TestParseWithRetryGivesUpAfterMaxAttempts(t *testing.T) {
+    tools := []registry.Tool{{Module: "fs", Name: "list_dir"}}
+    b := &fakeBackend{responses: []string{`{"module":"fs","intent":"delete_all","params":{}}`}}
+
+    _, err := parseWithRetry(context.Background(), b, "sys", "list the repo root", tools)
+    if err == nil {
+        t.Fatal("parseWithRetry: want an error, got nil")
+    }
+    if b.calls != maxParseAttempts {
+        t.Errorf("calls = %d, want %d (maxParseAttempts)", b.calls, maxParseAttempts)
+    }
+    if !strings.Contains(err.Error(), fmt.Sprintf("after %d attempts", maxParseAttempts)) {
+        t.Errorf("error = %q, want it to mention exhausting maxParseAttempts", err)
+    }
+}
+
+func TestSplitToolName(t *testing.T) {
+    cases := []struct {
+        full       string
+        wantModule string
+        wantName   string
+        wantOK     bool
+    }{
+        {"fs__list_dir", "fs", "list_dir", true},
+        {"fs__find__pattern", "fs", "find__pattern", true},
+        {"no-separator", "", "", false},
+    }
+    for _, c := range cases {
+        module, name, ok := splitToolName(c.full)
+        if module != c.wantModule || name != c.wantName || ok != c.wantOK {
+            t.Errorf("splitToolName(%q) = (%q, %q, %v), want (%q, %q, %v)",
+                c.full, module, name, ok, c.wantModule, c.wantName, c.wantOK)
+        }
+    }
+}
@@ -0,0 +1,138 @@
+package intentparser
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// ToolCallRecord is the tool call the assistant made in response to a
+// Message, recorded alongside its result so later turns in the same
+// Session can refer back to what was already done.
+type ToolCallRecord struct {
+    Module string            `json:"module"`
+    Name   string            `json:"intent"`
+    Params map[string]string `json:"params"`
+}
+
+// Message is one turn in a Session's history: either a user command, or
+// the assistant's resulting tool call and the output it produced once
+// executed.
+type Message struct {
+    Role     string          `json:"role"` // "user" | "assistant"
+    Content  string          `json:"content,omitempty"`
+    ToolCall *ToolCallRecord `json:"tool_call,omitempty"`
+    Result   string          `json:"result,omitempty"`
+}
+
+// Session persists a conversation's message history to
+// ~/.marco/sessions/<id>.json so LLMParseInSession can send the model the
+// full history on every follow-up, instead of starting over each call the
+// way LLMParse does.
+type Session struct {
+    ID       string    `json:"id"`
+    Messages []Message `json:"messages"`
+}
+
+// NewSession returns an empty Session with the given id. It is not
+// persisted until Save (or RecordResult) is called.
+func NewSession(id string) *Session {
+    return &Session{ID: id}
+}
+
+// sessionPath returns the file a Session with the given id is stored at.
+func sessionPath(id string) (string, error) {
+    if id == "" || filepath.Base(id) != id {
+        return "", fmt.Errorf("invalid session id %q", id)
+    }
+
+    home, err := os.UserHomeDir()
+    if err != nil {
+        return "", fmt.Errorf("could not determine home directory: %w", err)
+    }
+    return filepath.Join(home, ".marco", "sessions", id+".json"), nil
+}
+
+// LoadSession reads the session named id from disk, returning a fresh,
+// empty Session if none has been saved yet.
+func LoadSession(id string) (*Session, error) {
+    path, err := sessionPath(id)
+    if err != nil {
+        return nil, err
+    }
+
+    data, err := os.ReadFile(path)
+    if errors.Is(err, os.ErrNotExist) {
+        return NewSession(id), nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("reading session %s: %w", id, err)
+    }
+
+    var s Session
+    if err := json.Unmarshal(data, &s); err != nil {
+        return nil, fmt.Errorf("parsing session %s: %w", id, err)
+    }
+    return &s, nil
+}
+
+// Save writes the session to its file under ~/.marco/sessions, creating
+// the directory if needed.
+func (s *Session) Save() error {
+    path, err := sessionPath(s.ID)
+    if err != nil {
+        return err
+    }
+    if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+        return fmt.Errorf("creating sessions dir: %w", err)
+    }
+    data, err := json.MarshalIndent(s, "", "  ")
+    if err != nil {
+        return fmt.Errorf("marshaling session %s: %w", s.ID, err)
+    }
+    if err := os.WriteFile(path, data, 0o644); err != nil {
+        return fmt.Errorf("writing session %s: %w", s.ID, err)
+    }
+    return nil
+}
+
+// RecordResult attaches result to the most recent assistant message (the
+// output of executing its tool call) and persists the session. It is a
+// no-op if the session has no messages yet.
+func (s *Session) RecordResult(result string) error {
+    if len(s.Messages) == 0 {
+        return nil
+    }
+    s.Messages[len(s.Messages)-1].Result = result
+    return s.Save()
+}
+
+// transcript renders every message except the last (which is the user
+// command LLMParseInSession is currently parsing) as plain text context,
+// then appends that last command, so the composed string can be handed to
+// LLMParseForAgent as if it were a single userCmd.
+func (s *Session) transcript() string {
+    var sb strings.Builder
+    for i, m := range s.Messages {
+        if i == len(s.Messages)-1 {
+            break
+        }
+        switch m.Role {
+        case "user":
+            fmt.Fprintf(&sb, "User: %s\n", m.Content)
+        case "assistant":
+            if m.ToolCall != nil {
+                fmt.Fprintf(&sb, "Assistant called %s.%s(%v)", m.ToolCall.Module, m.ToolCall.Name, m.ToolCall.Params)
+                if m.Result != "" {
+                    fmt.Fprintf(&sb, " -> %s", m.Result)
+                }
+                sb.WriteString("\n")
+            }
+        }
+    }
+    sb.WriteString(s.Messages[len(s.Messages)-1].Content)
+    return sb.String()
+}
@@ -0,0 +1,77 @@
+package intentparser
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestSessionSaveAndLoadRoundTrip(t *testing.T) {
+    t.Setenv("HOME", t.TempDir())
+
+    s := NewSession("test-session")
+    s.Messages = append(s.Messages, Message{Role: "user", Content: "list the repo root"})
+    s.Messages = append(s.Messages, Message{
+        Role:     "assistant",
+        ToolCall: &ToolCallRecord{Module: "fs", Name: "list_dir", Params: map[string]string{"path": "."}},
+    })
+    if err := s.RecordResult("README.md\ngo.mod"); err != nil {
+        t.Fatalf("RecordResult: %v", err)
+    }
+
+    loaded, err := LoadSession("test-session")
+    if err != nil {
+        t.Fatalf("LoadSession: %v", err)
+    }
+    if len(loaded.Messages) != 2 {
+        t.Fatalf("loaded %d messages, want 2", len(loaded.Messages))
+    }
+    if loaded.Messages[1].Result != "README.md\ngo.mod" {
+        t.Errorf("Result = %q, want the recorded tool output", loaded.Messages[1].Result)
+    }
+    if loaded.Messages[1].ToolCall == nil || loaded.Messages[1].ToolCall.Name != "list_dir" {
+        t.Errorf("ToolCall = %+v, want the recorded list_dir call", loaded.Messages[1].ToolCall)
+    }
+}
+
+func TestLoadSessionMissingReturnsEmptySession(t *testing.T) {
+    t.Setenv("HOME", t.TempDir())
+
+    s, err := LoadSession("never-saved")
+    if err != nil {
+        t.Fatalf("LoadSession: %v", err)
+    }
+    if s.ID != "never-saved" || len(s.Messages) != 0 {
+        t.Errorf("LoadSession for a missing id = %+v, want an empty session with that id", s)
+    }
+}
+
+func TestLoadSessionRejectsPathTraversal(t *testing.T) {
+    t.Setenv("HOME", t.TempDir())
+
+    if _, err := LoadSession("../../../etc/passwd"); err == nil {
+        t.Fatal("LoadSession with a path-separator id: want error, got nil")
+    }
+    if _, err := LoadSession(""); err == nil {
+        t.Fatal("LoadSession with an empty id: want error, got nil")
+    }
+}
+
+func TestSessionTranscriptIncludesPriorTurnsAndCurrentCommand(t *testing.T) {
+    s := NewSession("t")
+    s.Messages = []Message{
+        {Role: "user", Content: "list the repo root"},
+        {Role: "assistant", ToolCall: &ToolCallRecord{Module: "fs", Name: "list_dir"}, Result: "README.md"},
+        {Role: "user", Content: "now do the same in tests/"},
+    }
+
+    got := s.transcript()
+    if !strings.Contains(got, "list the repo root") {
+        t.Errorf("transcript missing prior user turn: %q", got)
+    }
+    if !strings.Contains(got, "README.md") {
+        t.Errorf("transcript missing prior tool result: %q", got)
+    }
+    if !strings.Contains(got, "now do the same in tests/") {
+        t.Errorf("transcript missing the current command: %q", got)
+    }
+}
@@ -0,0 +1,64 @@
+package intentparser
+
+import (
+    "bytes"
+    "fmt"
+    "text/template"
+
+    "github.com/VanTheBast/marco/pkg/config"
+)
+
+// promptData is the value exposed to a model's prompt_templates.
+type promptData struct {
+    Command  string
+    Examples string
+}
+
+// renderSystemPrompt renders model's system template, falling back to the
+// package default when model is nil or defines none.
+func renderSystemPrompt(model *config.Model) string {
+    if model == nil || model.PromptTemplates.System == "" {
+        return systemPrompt
+    }
+    return execTemplate("system", model.PromptTemplates.System, promptData{})
+}
+
+// renderIntentPrompt renders model's intent_parse template with userCmd,
+// falling back to the package default when model is nil or defines none.
+// With the default prompt, model's few-shot examples template, if set, is
+// rendered ahead of it so example command/intent pairs can steer parsing
+// without requiring a full intent_parse override. A custom intent_parse
+// template receives the rendered examples as .Examples and includes them
+// only where it references that field.
+func renderIntentPrompt(model *config.Model, userCmd string) string {
+    examples := renderExamples(model)
+
+    if model == nil || model.PromptTemplates.IntentParse == "" {
+        return examples + fmt.Sprintf(promptTemplate, userCmd)
+    }
+    return execTemplate("intent_parse", model.PromptTemplates.IntentParse, promptData{Command: userCmd, Examples: examples})
+}
+
+// renderExamples renders model's few-shot examples template, returning ""
+// when model is nil or defines none.
+func renderExamples(model *config.Model) string {
+    if model == nil || model.PromptTemplates.Examples == "" {
+        return ""
+    }
+    return execTemplate("examples", model.PromptTemplates.Examples, promptData{})
+}
+
+// execTemplate renders a named Go template against data, falling back to
+// the raw template text if it fails to parse or execute so a bad model
+// config degrades to a literal prompt instead of an error.
+func execTemplate(name, tmplText string, data promptData) string {
+    tmpl, err := template.New(name).Parse(tmplText)
+    if err != nil {
+        return tmplText
+    }
+    var buf bytes.Buffer
+    if err := tmpl.Execute(&buf, data); err != nil {
+        return tmplText
+    }
+    return buf.String()
+}
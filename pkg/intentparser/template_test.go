@@ -0,0 +1,64 @@
+package intentparser
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/VanTheBast/marco/pkg/config"
+)
+
+func TestRenderSystemPromptFallsBackToDefault(t *testing.T) {
+    if got := renderSystemPrompt(nil); got != systemPrompt {
+        t.Errorf("renderSystemPrompt(nil) = %q, want the package default", got)
+    }
+    model := &config.Model{}
+    if got := renderSystemPrompt(model); got != systemPrompt {
+        t.Errorf("renderSystemPrompt with no system template = %q, want the package default", got)
+    }
+}
+
+func TestRenderSystemPromptUsesModelTemplate(t *testing.T) {
+    model := &config.Model{PromptTemplates: config.PromptTemplates{System: "Custom system prompt."}}
+    if got := renderSystemPrompt(model); got != "Custom system prompt." {
+        t.Errorf("renderSystemPrompt = %q, want the model's template", got)
+    }
+}
+
+func TestRenderIntentPromptFallsBackToDefault(t *testing.T) {
+    got := renderIntentPrompt(nil, "list the repo root")
+    if !strings.Contains(got, "list the repo root") {
+        t.Errorf("renderIntentPrompt(nil, ...) = %q, want it to include userCmd", got)
+    }
+}
+
+func TestRenderIntentPromptUsesModelTemplate(t *testing.T) {
+    model := &config.Model{PromptTemplates: config.PromptTemplates{IntentParse: "Parse: {{.Command}}"}}
+    got := renderIntentPrompt(model, "list the repo root")
+    if got != "Parse: list the repo root" {
+        t.Errorf("renderIntentPrompt = %q, want the model's template rendered with Command", got)
+    }
+}
+
+func TestRenderIntentPromptPrependsExamples(t *testing.T) {
+    model := &config.Model{PromptTemplates: config.PromptTemplates{
+        Examples: "Example: \"list files\" -> fs.list_dir\n",
+    }}
+    got := renderIntentPrompt(model, "list the repo root")
+    examplesIdx := strings.Index(got, "Example: \"list files\"")
+    commandIdx := strings.Index(got, "list the repo root")
+    if examplesIdx < 0 || commandIdx < 0 || examplesIdx > commandIdx {
+        t.Errorf("renderIntentPrompt = %q, want the examples template rendered ahead of the command", got)
+    }
+}
+
+func TestRenderIntentPromptPassesExamplesToCustomTemplate(t *testing.T) {
+    model := &config.Model{PromptTemplates: config.PromptTemplates{
+        IntentParse: "{{.Examples}}Parse: {{.Command}}",
+        Examples:    "Example: \"list files\" -> fs.list_dir\n",
+    }}
+    got := renderIntentPrompt(model, "list the repo root")
+    want := "Example: \"list files\" -> fs.list_dir\nParse: list the repo root"
+    if got != want {
+        t.Errorf("renderIntentPrompt = %q, want %q", got, want)
+    }
+}
@@ -1,27 +1,126 @@
+// Package fs is the MCP module that exposes filesystem intents (listing
+// directories, searching file contents) to the orchestrator.
 package fs
 
-import "fmt"
-// import "os/exec"
-
-// HandleIntent handles file-system related intents.
-func HandleIntent(name string, params map[string]string) (string, error) {
-    // switch name {
-    // case "list_files":
-    //     dir := params["directory"]
-    //     if dir == "" {
-    //         dir = "." // Default to current directory if not specified
-    //     }
-    //     cmd := exec.Command("ls", dir)
-    //     output, err := cmd.Output()
-    //     if err != nil {
-    //         return "", fmt.Errorf("error listing files in %s: %w", dir, err)
-    //     }
-    //     return string(output), nil
-    // default:
-    //     return "", fmt.Errorf("unsupported fs intent: %s", name)
-    // }
-    // fmt.Println("Handling intent:", name)
-    // fmt.Println("With params:", params)
-    
-    return fmt.Sprintf("Handled fs intent: %s with params: %v", name, params), nil
+import (
+    "bufio"
+    "context"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/VanTheBast/marco/pkg/mcp/registry"
+)
+
+func init() {
+    registry.Default.Register(registry.Tool{
+        Module:      "fs",
+        Name:        "list_dir",
+        Description: "List the files and directories directly inside a path.",
+        Parameters: map[string]interface{}{
+            "type": "object",
+            "properties": map[string]interface{}{
+                "path": map[string]interface{}{
+                    "type":        "string",
+                    "description": "Directory to list. Defaults to the current directory.",
+                },
+            },
+        },
+        Handler: listDir,
+    })
+
+    registry.Default.Register(registry.Tool{
+        Module:      "fs",
+        Name:        "find_pattern",
+        Description: "Recursively search files under a path for a literal text pattern.",
+        Parameters: map[string]interface{}{
+            "type": "object",
+            "properties": map[string]interface{}{
+                "pattern": map[string]interface{}{
+                    "type":        "string",
+                    "description": "Literal text to search for.",
+                },
+                "path": map[string]interface{}{
+                    "type":        "string",
+                    "description": "Root directory to search. Defaults to the current directory.",
+                },
+            },
+            "required": []string{"pattern"},
+        },
+        StreamHandler: findPatternStream,
+    })
+}
+
+// listDir implements the fs.list_dir intent.
+func listDir(params map[string]string) (string, error) {
+    dir := params["path"]
+    if dir == "" {
+        dir = "."
+    }
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        return "", fmt.Errorf("list_dir: reading %s: %w", dir, err)
+    }
+    var sb strings.Builder
+    for _, e := range entries {
+        fmt.Fprintln(&sb, e.Name())
+    }
+    return sb.String(), nil
+}
+
+// findPatternStream implements the fs.find_pattern intent, emitting each
+// match as it's found instead of buffering the whole search. It selects
+// on ctx while sending so an abandoned stream (e.g. a disconnected SSE
+// client) stops the walk instead of running it to completion with
+// nothing left to read its events.
+func findPatternStream(ctx context.Context, params map[string]string) (<-chan registry.Event, error) {
+    pattern := params["pattern"]
+    if pattern == "" {
+        return nil, fmt.Errorf("find_pattern: missing required param %q", "pattern")
+    }
+    root := params["path"]
+    if root == "" {
+        root = "."
+    }
+
+    events := make(chan registry.Event)
+    go func() {
+        defer close(events)
+        err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+            if err != nil {
+                return err
+            }
+            if d.IsDir() {
+                return nil
+            }
+            f, err := os.Open(path)
+            if err != nil {
+                // Skip files we can't read rather than failing the whole search.
+                return nil
+            }
+            defer f.Close()
+
+            scanner := bufio.NewScanner(f)
+            lineNo := 0
+            for scanner.Scan() {
+                lineNo++
+                if strings.Contains(scanner.Text(), pattern) {
+                    select {
+                    case events <- registry.Event{Data: fmt.Sprintf("%s:%d: %s\n", path, lineNo, scanner.Text())}:
+                    case <-ctx.Done():
+                        return ctx.Err()
+                    }
+                }
+            }
+            return nil
+        })
+        if err != nil && ctx.Err() == nil {
+            select {
+            case events <- registry.Event{Err: fmt.Errorf("find_pattern: walking %s: %w", root, err)}:
+            case <-ctx.Done():
+            }
+        }
+    }()
+    return events, nil
 }
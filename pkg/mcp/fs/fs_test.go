@@ -0,0 +1,109 @@
+package fs
+
+import (
+    "context"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+func TestListDir(t *testing.T) {
+    dir := t.TempDir()
+    for _, name := range []string{"a.txt", "b.txt"} {
+        if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0o644); err != nil {
+            t.Fatalf("writing %s: %v", name, err)
+        }
+    }
+
+    out, err := listDir(map[string]string{"path": dir})
+    if err != nil {
+        t.Fatalf("listDir: %v", err)
+    }
+    for _, name := range []string{"a.txt", "b.txt"} {
+        if !strings.Contains(out, name) {
+            t.Errorf("listDir output %q missing %q", out, name)
+        }
+    }
+}
+
+func TestListDirMissingPathDefaultsToCurrentDir(t *testing.T) {
+    if _, err := listDir(map[string]string{}); err != nil {
+        t.Errorf("listDir with no path: %v", err)
+    }
+}
+
+func TestFindPatternStreamMissingPattern(t *testing.T) {
+    _, err := findPatternStream(context.Background(), map[string]string{"path": t.TempDir()})
+    if err == nil {
+        t.Fatal("findPatternStream with no pattern: want error, got nil")
+    }
+}
+
+func TestFindPatternStreamEmitsOneEventPerMatch(t *testing.T) {
+    dir := t.TempDir()
+    if err := os.WriteFile(filepath.Join(dir, "match.txt"), []byte("hello\nneedle\nworld\nneedle again\n"), 0o644); err != nil {
+        t.Fatalf("writing match.txt: %v", err)
+    }
+    if err := os.WriteFile(filepath.Join(dir, "nomatch.txt"), []byte("nothing here\n"), 0o644); err != nil {
+        t.Fatalf("writing nomatch.txt: %v", err)
+    }
+
+    events, err := findPatternStream(context.Background(), map[string]string{"pattern": "needle", "path": dir})
+    if err != nil {
+        t.Fatalf("findPatternStream: %v", err)
+    }
+
+    var matches []string
+    for e := range events {
+        if e.Err != nil {
+            t.Fatalf("unexpected event error: %v", e.Err)
+        }
+        matches = append(matches, e.Data)
+    }
+
+    if len(matches) != 2 {
+        t.Fatalf("got %d matches, want 2: %v", len(matches), matches)
+    }
+    for _, m := range matches {
+        if !strings.Contains(m, "needle") {
+            t.Errorf("match %q doesn't contain the search pattern", m)
+        }
+    }
+}
+
+func TestFindPatternStreamStopsOnContextCancellation(t *testing.T) {
+    dir := t.TempDir()
+    if err := os.WriteFile(filepath.Join(dir, "match.txt"), []byte("needle\n"), 0o644); err != nil {
+        t.Fatalf("writing match.txt: %v", err)
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    events, err := findPatternStream(ctx, map[string]string{"pattern": "needle", "path": dir})
+    if err != nil {
+        t.Fatalf("findPatternStream: %v", err)
+    }
+    cancel()
+
+    for range events {
+        // Drain until the goroutine notices cancellation and closes the
+        // channel; a hang here means it leaked on a blocked send.
+    }
+}
+
+func TestFindPatternStreamWalkErrorEmitsErrEvent(t *testing.T) {
+    events, err := findPatternStream(context.Background(), map[string]string{"pattern": "needle", "path": filepath.Join(t.TempDir(), "does-not-exist")})
+    if err != nil {
+        t.Fatalf("findPatternStream: %v", err)
+    }
+
+    var sawErr bool
+    for e := range events {
+        if e.Err != nil {
+            sawErr = true
+        }
+    }
+    if !sawErr {
+        t.Error("expected an Event.Err for a missing root directory, got none")
+    }
+}
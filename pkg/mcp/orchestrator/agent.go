@@ -0,0 +1,30 @@
+package orchestrator
+
+import "github.com/VanTheBast/marco/pkg/config"
+
+// builtinAgents ships a few ready-made profiles so safety-scoped usage
+// doesn't require writing a config file.
+var builtinAgents = map[string]config.Agent{
+    "readonly-fs": {
+        Name:         "readonly-fs",
+        SystemPrompt: "You may only list directories and search file contents. Never modify anything.",
+        Allow:        []string{"fs.list_dir", "fs.find_pattern"},
+    },
+    "canvas-editor": {
+        Name:         "canvas-editor",
+        SystemPrompt: "You help the user edit their canvas.",
+        Allow:        []string{"canvas"},
+    },
+}
+
+// LookupAgent returns the named agent, preferring one configured in
+// cfg.Agents over a built-in of the same name.
+func LookupAgent(cfg *config.Config, name string) (config.Agent, bool) {
+    for _, a := range cfg.Agents {
+        if a.Name == name {
+            return a, true
+        }
+    }
+    a, ok := builtinAgents[name]
+    return a, ok
+}
@@ -0,0 +1,38 @@
+package orchestrator
+
+import (
+    "testing"
+
+    "github.com/VanTheBast/marco/pkg/config"
+)
+
+func TestLookupAgentBuiltin(t *testing.T) {
+    cfg := &config.Config{}
+    a, ok := LookupAgent(cfg, "readonly-fs")
+    if !ok {
+        t.Fatalf("expected built-in agent %q to be found", "readonly-fs")
+    }
+    if !a.Allows("fs", "list_dir") || a.Allows("fs", "delete") {
+        t.Errorf("readonly-fs allow-list = %v, want only fs.list_dir/fs.find_pattern", a.Allow)
+    }
+}
+
+func TestLookupAgentUnknown(t *testing.T) {
+    cfg := &config.Config{}
+    if _, ok := LookupAgent(cfg, "does-not-exist"); ok {
+        t.Errorf("expected unknown agent name to report not found")
+    }
+}
+
+func TestLookupAgentConfigOverridesBuiltin(t *testing.T) {
+    cfg := &config.Config{
+        Agents: []config.Agent{{Name: "readonly-fs", Allow: []string{"shell.exec"}}},
+    }
+    a, ok := LookupAgent(cfg, "readonly-fs")
+    if !ok {
+        t.Fatalf("expected agent %q to be found", "readonly-fs")
+    }
+    if !a.Allows("shell", "exec") {
+        t.Errorf("expected the configured readonly-fs to take precedence over the built-in")
+    }
+}
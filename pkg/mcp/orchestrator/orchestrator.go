@@ -1,18 +1,53 @@
 package orchestrator
 
 import (
+    "context"
     "fmt"
 
+    "github.com/VanTheBast/marco/pkg/config"
     "github.com/VanTheBast/marco/pkg/intentparser"
-    "github.com/VanTheBast/marco/pkg/mcp/fs"
+    "github.com/VanTheBast/marco/pkg/mcp/registry"
 )
 
-// RouteIntent routes the parsed intent to the appropriate MCP module.
-func RouteIntent(intent *intentparser.Intent) (string, error) {
-    switch intent.Module {
-    case "fs":
-        return fs.HandleIntent(intent.Name, intent.Params)
-    default:
-        return "", fmt.Errorf("Unknown module: %s", intent.Module)
+// RouteIntent routes the parsed intent to the appropriate MCP module by
+// looking it up in the registry that modules populate at init time. It is
+// equivalent to RouteIntentForAgent with a nil agent, i.e. no allow-list
+// restriction.
+func RouteIntent(ctx context.Context, intent *intentparser.Intent) (<-chan registry.Event, error) {
+    return RouteIntentForAgent(ctx, intent, nil)
+}
+
+// RouteIntentForAgent is like RouteIntent, but rejects the intent unless
+// agent permits it. A nil agent means no restriction.
+//
+// The result is always a channel of incremental events: modules that only
+// implement Handler get a single-event channel, so callers can consume
+// every intent the same way regardless of whether it streams. ctx is
+// passed through to StreamHandler so a caller that stops reading (e.g. a
+// disconnected SSE client) can signal the module to stop producing.
+func RouteIntentForAgent(ctx context.Context, intent *intentparser.Intent, agent *config.Agent) (<-chan registry.Event, error) {
+    if agent != nil && !agent.Allows(intent.Module, intent.Name) {
+        return nil, fmt.Errorf("agent %q is not permitted to invoke %s/%s", agent.Name, intent.Module, intent.Name)
+    }
+
+    tool, ok := registry.Default.Lookup(intent.Module, intent.Name)
+    if !ok {
+        return nil, fmt.Errorf("unknown module/intent: %s/%s", intent.Module, intent.Name)
+    }
+
+    if tool.StreamHandler != nil {
+        return tool.StreamHandler(ctx, intent.Params)
+    }
+    if tool.Handler == nil {
+        return nil, fmt.Errorf("module/intent %s/%s has no handler registered", intent.Module, intent.Name)
+    }
+
+    out, err := tool.Handler(intent.Params)
+    if err != nil {
+        return nil, err
     }
+    events := make(chan registry.Event, 1)
+    events <- registry.Event{Data: out}
+    close(events)
+    return events, nil
 }
@@ -0,0 +1,26 @@
+package orchestrator
+
+import (
+    "context"
+    "testing"
+
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/intentparser"
+)
+
+func TestRouteIntentForAgentRejectsDisallowedIntent(t *testing.T) {
+    agent := &config.Agent{Name: "readonly-fs", Allow: []string{"fs.list_dir"}}
+    intent := &intentparser.Intent{Module: "fs", Name: "delete_all"}
+
+    if _, err := RouteIntentForAgent(context.Background(), intent, agent); err == nil {
+        t.Errorf("expected RouteIntentForAgent to reject an intent outside the allow-list")
+    }
+}
+
+func TestRouteIntentForAgentUnknownIntent(t *testing.T) {
+    intent := &intentparser.Intent{Module: "does-not-exist", Name: "noop"}
+
+    if _, err := RouteIntentForAgent(context.Background(), intent, nil); err == nil {
+        t.Errorf("expected RouteIntentForAgent to error on an unregistered module/intent")
+    }
+}
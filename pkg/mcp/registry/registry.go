@@ -0,0 +1,95 @@
+// Package registry is the catalog of MCP module intents, described as
+// JSON-schema tool definitions. Modules populate it from their init()
+// functions; the orchestrator reads it to dispatch intents, and
+// intentparser reads it to build function-calling tool definitions (or a
+// constrained-decoding grammar) for the LLM backend.
+package registry
+
+import (
+    "context"
+    "sync"
+)
+
+// Handler executes a single intent within a module, given its params, and
+// returns its full output at once.
+type Handler func(params map[string]string) (string, error)
+
+// Event is one incremental update from a StreamHandler invocation. A
+// non-nil Err terminates the stream; the channel is closed after it.
+type Event struct {
+    Data string
+    Err  error
+}
+
+// StreamHandler executes a single intent the same way Handler does, but
+// emits its output incrementally instead of blocking until it's complete.
+// The channel is closed when the intent finishes. Implementations must
+// select on ctx so an abandoned stream (e.g. a disconnected SSE client)
+// stops the underlying work instead of running to completion with
+// nothing left to read its events.
+type StreamHandler func(ctx context.Context, params map[string]string) (<-chan Event, error)
+
+// Tool describes a module intent as an OpenAI-style function/tool
+// definition (name, JSON-schema params) plus the handler(s) that execute
+// it. Exactly one of Handler or StreamHandler should be set; modules use
+// StreamHandler for intents whose output is naturally incremental (e.g.
+// a recursive file search over a large tree).
+type Tool struct {
+    Module        string
+    Name          string
+    Description   string
+    Parameters    map[string]interface{} // JSON schema
+    Handler       Handler
+    StreamHandler StreamHandler
+}
+
+// Registry is the catalog of every module intent Marco can execute.
+type Registry struct {
+    mu    sync.RWMutex
+    tools map[string]map[string]Tool // module -> intent name -> Tool
+}
+
+// New returns an empty Registry.
+func New() *Registry {
+    return &Registry{tools: make(map[string]map[string]Tool)}
+}
+
+// Default is the process-wide registry that modules populate from their
+// init() functions.
+var Default = New()
+
+// Register adds a tool to the registry, replacing any existing tool with
+// the same module/name.
+func (r *Registry) Register(tool Tool) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if r.tools[tool.Module] == nil {
+        r.tools[tool.Module] = make(map[string]Tool)
+    }
+    r.tools[tool.Module][tool.Name] = tool
+}
+
+// All returns every registered tool, in no particular order.
+func (r *Registry) All() []Tool {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    var out []Tool
+    for _, byName := range r.tools {
+        for _, t := range byName {
+            out = append(out, t)
+        }
+    }
+    return out
+}
+
+// Lookup returns the tool registered for module/name, if any.
+func (r *Registry) Lookup(module, name string) (Tool, bool) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    byName, ok := r.tools[module]
+    if !ok {
+        return Tool{}, false
+    }
+    t, ok := byName[name]
+    return t, ok
+}
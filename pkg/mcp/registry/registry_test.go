@@ -0,0 +1,51 @@
+package registry
+
+import "testing"
+
+func TestRegisterAndLookup(t *testing.T) {
+    r := New()
+    r.Register(Tool{Module: "fs", Name: "list_dir", Description: "list a directory"})
+
+    tool, ok := r.Lookup("fs", "list_dir")
+    if !ok {
+        t.Fatalf("expected fs/list_dir to be registered")
+    }
+    if tool.Description != "list a directory" {
+        t.Errorf("Description = %q, want %q", tool.Description, "list a directory")
+    }
+
+    if _, ok := r.Lookup("fs", "missing"); ok {
+        t.Errorf("expected fs/missing to be unregistered")
+    }
+    if _, ok := r.Lookup("missing", "list_dir"); ok {
+        t.Errorf("expected missing/list_dir to be unregistered")
+    }
+}
+
+func TestRegisterReplacesExisting(t *testing.T) {
+    r := New()
+    r.Register(Tool{Module: "fs", Name: "list_dir", Description: "v1"})
+    r.Register(Tool{Module: "fs", Name: "list_dir", Description: "v2"})
+
+    tool, ok := r.Lookup("fs", "list_dir")
+    if !ok {
+        t.Fatalf("expected fs/list_dir to be registered")
+    }
+    if tool.Description != "v2" {
+        t.Errorf("Description = %q, want %q", tool.Description, "v2")
+    }
+    if len(r.All()) != 1 {
+        t.Errorf("All() = %d tools, want 1", len(r.All()))
+    }
+}
+
+func TestAllReturnsEveryTool(t *testing.T) {
+    r := New()
+    r.Register(Tool{Module: "fs", Name: "list_dir"})
+    r.Register(Tool{Module: "fs", Name: "find_pattern"})
+    r.Register(Tool{Module: "canvas", Name: "draw"})
+
+    if len(r.All()) != 3 {
+        t.Errorf("All() = %d tools, want 3", len(r.All()))
+    }
+}
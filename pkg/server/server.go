@@ -0,0 +1,103 @@
+// Package server exposes Marco's intent parsing and execution over local
+// HTTP, streaming progress as Server-Sent Events so a client can watch a
+// long-running intent (e.g. a find_pattern search across a large tree)
+// produce results incrementally instead of waiting for it to finish.
+package server
+
+import (
+    "fmt"
+    "net/http"
+    "strings"
+
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/intentparser"
+    "github.com/VanTheBast/marco/pkg/mcp/orchestrator"
+)
+
+// Handler returns an http.Handler that parses the "cmd" query parameter
+// as a natural-language command and streams its execution as SSE events.
+// An optional "agent" query parameter scopes the parse and the route to
+// that agent's allow-listed modules/intents, same as `marco -a`.
+func Handler(cfg *config.Config) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        handleParse(w, r, cfg)
+    })
+}
+
+func handleParse(w http.ResponseWriter, r *http.Request, cfg *config.Config) {
+    userCmd := r.URL.Query().Get("cmd")
+    if userCmd == "" {
+        http.Error(w, "missing cmd query parameter", http.StatusBadRequest)
+        return
+    }
+
+    flusher, ok := w.(http.Flusher)
+    if !ok {
+        http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+        return
+    }
+
+    w.Header().Set("Content-Type", "text/event-stream")
+    w.Header().Set("Cache-Control", "no-cache")
+    w.Header().Set("Connection", "keep-alive")
+
+    var agent *config.Agent
+    if agentName := r.URL.Query().Get("agent"); agentName != "" {
+        a, ok := orchestrator.LookupAgent(cfg, agentName)
+        if !ok {
+            writeSSE(w, "error", fmt.Sprintf("unknown agent %q", agentName))
+            flusher.Flush()
+            return
+        }
+        agent = &a
+    }
+
+    intent, err := intentparser.LLMParseForAgent(r.Context(), userCmd, r.URL.Query().Get("model"), agent)
+    if err != nil {
+        writeSSE(w, "error", err.Error())
+        flusher.Flush()
+        return
+    }
+
+    events, err := orchestrator.RouteIntentForAgent(r.Context(), intent, agent)
+    if err != nil {
+        writeSSE(w, "error", err.Error())
+        flusher.Flush()
+        return
+    }
+
+    for {
+        select {
+        case <-r.Context().Done():
+            // The client disconnected; RouteIntentForAgent threaded our
+            // context down to the stream handler, so it stops producing
+            // instead of running an abandoned find_pattern walk to
+            // completion.
+            return
+        case event, ok := <-events:
+            if !ok {
+                writeSSE(w, "done", "")
+                flusher.Flush()
+                return
+            }
+            if event.Err != nil {
+                writeSSE(w, "error", event.Err.Error())
+                flusher.Flush()
+                return
+            }
+            writeSSE(w, "message", event.Data)
+            flusher.Flush()
+        }
+    }
+}
+
+// writeSSE writes event as an SSE event with the given data, splitting
+// multi-line data across multiple "data:" fields per the SSE spec so
+// EventSource clients reassemble it into a single message.
+func writeSSE(w http.ResponseWriter, event, data string) {
+    fmt.Fprintf(w, "event: %s\n", event)
+    for _, line := range strings.Split(data, "\n") {
+        fmt.Fprintf(w, "data: %s\n", line)
+    }
+    fmt.Fprint(w, "\n")
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "github.com/VanTheBast/marco/pkg/config"
+    "github.com/VanTheBast/marco/pkg/intentparser"
+    "github.com/VanTheBast/marco/pkg/mcp/registry"
+)
+
+func TestWriteSSESingleLine(t *testing.T) {
+    w := httptest.NewRecorder()
+    writeSSE(w, "message", "hello")
+
+    want := "event: message\ndata: hello\n\n"
+    if got := w.Body.String(); got != want {
+        t.Errorf("writeSSE output = %q, want %q", got, want)
+    }
+}
+
+func TestWriteSSESplitsMultiLineData(t *testing.T) {
+    w := httptest.NewRecorder()
+    writeSSE(w, "message", "line1\nline2\nline3")
+
+    want := "event: message\ndata: line1\ndata: line2\ndata: line3\n\n"
+    if got := w.Body.String(); got != want {
+        t.Errorf("writeSSE output = %q, want %q", got, want)
+    }
+}
+
+func TestWriteSSEEmptyData(t *testing.T) {
+    w := httptest.NewRecorder()
+    writeSSE(w, "done", "")
+
+    want := "event: done\ndata: \n\n"
+    if got := w.Body.String(); got != want {
+        t.Errorf("writeSSE output = %q, want %q", got, want)
+    }
+}
+
+// fakeOllamaServer responds to every /api/generate call with the given
+// raw "response" string, regardless of prompt, so tests can pin exactly
+// what the "model" returns without a real LLM.
+func fakeOllamaServer(t *testing.T, response string) *httptest.Server {
+    t.Helper()
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(map[string]interface{}{"response": response, "done": true})
+    }))
+    t.Cleanup(srv.Close)
+    return srv
+}
+
+// testAgentAndTools registers an allow-listed and a disallowed tool under
+// registry.Default and returns a *config.Agent permitting only the
+// former, so handleParse's allow-list enforcement can be exercised
+// end-to-end without depending on the real fs module being registered.
+func testAgentAndTools(t *testing.T) *config.Agent {
+    t.Helper()
+
+    registry.Default.Register(registry.Tool{
+        Module:      "testmod",
+        Name:        "safe_op",
+        Description: "an intent the agent is allowed to invoke",
+        Parameters:  map[string]interface{}{"type": "object"},
+        Handler: func(params map[string]string) (string, error) {
+            return "ok", nil
+        },
+    })
+    registry.Default.Register(registry.Tool{
+        Module:      "testmod",
+        Name:        "dangerous_op",
+        Description: "an intent the agent must never be able to invoke",
+        Parameters:  map[string]interface{}{"type": "object"},
+        Handler: func(params map[string]string) (string, error) {
+            return "should not have run", nil
+        },
+    })
+
+    return &config.Agent{Name: "only-safe", Allow: []string{"testmod.safe_op"}}
+}
+
+func TestHandleParseMissingCmd(t *testing.T) {
+    cfg := &config.Config{}
+    req := httptest.NewRequest(http.MethodGet, "/?agent=only-safe", nil)
+    w := httptest.NewRecorder()
+
+    handleParse(w, req, cfg)
+
+    if w.Code != http.StatusBadRequest {
+        t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+    }
+}
+
+func TestHandleParseUnknownAgent(t *testing.T) {
+    cfg := &config.Config{}
+    req := httptest.NewRequest(http.MethodGet, "/?cmd=do+something&agent=does-not-exist", nil)
+    w := httptest.NewRecorder()
+
+    handleParse(w, req, cfg)
+
+    if !strings.Contains(w.Body.String(), "event: error") || !strings.Contains(w.Body.String(), "unknown agent") {
+        t.Errorf("body = %q, want an SSE error event naming the unknown agent", w.Body.String())
+    }
+}
+
+func TestHandleParseRejectsIntentOutsideAgentAllowList(t *testing.T) {
+    agent := testAgentAndTools(t)
+    cfg := &config.Config{
+        Backend: config.Backend{Type: "ollama", Endpoint: fakeOllamaServer(t, `{"module":"testmod","intent":"dangerous_op","params":{}}`).URL},
+        Agents:  []config.Agent{*agent},
+    }
+    if err := intentparser.Init(cfg); err != nil {
+        t.Fatalf("Init: %v", err)
+    }
+
+    req := httptest.NewRequest(http.MethodGet, "/?cmd=do+the+dangerous+thing&agent=only-safe", nil)
+    w := httptest.NewRecorder()
+
+    handleParse(w, req, cfg)
+
+    if !strings.Contains(w.Body.String(), "event: error") {
+        t.Errorf("body = %q, want an SSE error event rejecting the disallowed intent", w.Body.String())
+    }
+    if strings.Contains(w.Body.String(), "should not have run") {
+        t.Errorf("body = %q, the disallowed handler must never run", w.Body.String())
+    }
+}
+
+func TestHandleParseRoutesAllowedIntent(t *testing.T) {
+    agent := testAgentAndTools(t)
+    cfg := &config.Config{
+        Backend: config.Backend{Type: "ollama", Endpoint: fakeOllamaServer(t, `{"module":"testmod","intent":"safe_op","params":{}}`).URL},
+        Agents:  []config.Agent{*agent},
+    }
+    if err := intentparser.Init(cfg); err != nil {
+        t.Fatalf("Init: %v", err)
+    }
+
+    req := httptest.NewRequest(http.MethodGet, "/?cmd=do+the+safe+thing&agent=only-safe", nil)
+    w := httptest.NewRecorder()
+
+    handleParse(w, req, cfg)
+
+    if !strings.Contains(w.Body.String(), "event: message") || !strings.Contains(w.Body.String(), "data: ok") {
+        t.Errorf("body = %q, want the allowed intent's result streamed back", w.Body.String())
+    }
+}